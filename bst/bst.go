@@ -3,13 +3,9 @@ package bst
 
 import (
 	"errors"
-	"io"
 	"math"
-	"os"
 )
 
-var Output io.Writer = os.Stdout
-
 // Tree struct defines a tree node that store an int Item
 // It is a recursive data structure with tree nodes as children and parent
 type Tree struct {
@@ -172,6 +168,30 @@ func (t *Tree) Delete(item int) (bool, error) {
 	return true, nil
 }
 
+// LowestCommonAncestor finds the deepest node that has both items in its
+// subtree. Since every item smaller than a node lies to its left and every
+// larger one to its right, this is the first node whose item falls between
+// the two, found in O(h) time.
+func (t *Tree) LowestCommonAncestor(a, b int) (*Tree, error) {
+	if _, err := t.Search(a); err != nil {
+		return nil, err
+	}
+	if _, err := t.Search(b); err != nil {
+		return nil, err
+	}
+	node := t
+	for {
+		switch {
+		case a < node.Item && b < node.Item:
+			node = node.Left
+		case a > node.Item && b > node.Item:
+			node = node.Right
+		default:
+			return node, nil
+		}
+	}
+}
+
 // IsBalanced checks if the BST is balanced (i.e: no height distance > 2)
 func (t *Tree) IsBalanced() bool {
 	max := t.MaxDepth()
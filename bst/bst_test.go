@@ -98,6 +98,22 @@ func TestIsBalanced(t *testing.T) {
 	}
 }
 
+func TestLowestCommonAncestor(t *testing.T) {
+	tree := initTree()
+	cases := [][3]int{{1, 3, 2}, {1, 7, 4}, {5, 7, 6}, {3, 17, 8}, {2, 3, 2}}
+	for _, c := range cases {
+		lca, err := tree.LowestCommonAncestor(c[0], c[1])
+		if err != nil {
+			t.Error(err.Error())
+		} else if lca.Item != c[2] {
+			t.Error("Incorrect lowest common ancestor found")
+		}
+	}
+	if _, err := tree.LowestCommonAncestor(1, 42); err == nil {
+		t.Error("Found an ancestor for an item not in the tree")
+	}
+}
+
 func initTree() *Tree {
 	tree := NewTree(8, nil)
 	tree.Insert(4)
@@ -0,0 +1,251 @@
+// Command graphtool loads a graph from a file and answers questions about it
+// from the command line.
+//
+// Usage:
+//
+//	graphtool [-directed] [-json] <command> <file> [arguments]
+//
+// Text files list one edge per line as two vertices and an optional weight.
+// Files ending in .json hold a graph encoded by the graph package, which
+// records whether it is directed. A file name of - reads the graph from
+// standard input.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fabioberger/data-structures/graph"
+)
+
+// A command is a graphtool subcommand. It returns the value printed with
+// -json and the text printed otherwise.
+type command struct {
+	args  []string // Names of the arguments after the graph file
+	usage string
+	run   func(g *graph.Graph, args []int) (interface{}, string, error)
+}
+
+var commands = map[string]command{
+	"path":         {[]string{"from", "to"}, "shortest path by number of edges", findPath},
+	"components":   {nil, "connected components, ignoring edge direction", components},
+	"cycles":       {nil, "a cycle of the graph, if there is one", findCycle},
+	"articulation": {nil, "articulation vertices of an undirected graph", articulation},
+	"toposort":     {nil, "topological order of a directed acyclic graph", toposort},
+	"mst":          {nil, "minimum spanning forest of an undirected graph", spanningTree},
+	"dot":          {nil, "the graph in Graphviz DOT format", dot},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes graphtool with the given arguments and returns the exit status:
+// 0 on success, 1 when the command fails and 2 for invalid usage
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("graphtool", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	directed := flags.Bool("directed", false, "read text files as directed graphs")
+	asJSON := flags.Bool("json", false, "print results as JSON")
+	flags.Usage = func() { usage(flags) }
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() < 2 {
+		usage(flags)
+		return 2
+	}
+	name, file := flags.Arg(0), flags.Arg(1)
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(stderr, "graphtool: unknown command %q\n", name)
+		usage(flags)
+		return 2
+	}
+	if flags.NArg()-2 != len(cmd.args) {
+		fmt.Fprintf(stderr, "graphtool: %v expects arguments: <file> %v\n", name, argNames(cmd.args))
+		return 2
+	}
+
+	g, err := load(file, stdin, *directed)
+	if err != nil {
+		fmt.Fprintf(stderr, "graphtool: %v\n", err)
+		return 1
+	}
+	vertices := []int{}
+	for _, arg := range flags.Args()[2:] {
+		v, err := strconv.Atoi(arg)
+		if err != nil || v < 1 || v > g.NumVertices() {
+			fmt.Fprintf(stderr, "graphtool: %q is not a vertex of the graph\n", arg)
+			return 1
+		}
+		vertices = append(vertices, v)
+	}
+
+	result, text, err := cmd.run(g, vertices)
+	if err != nil {
+		fmt.Fprintf(stderr, "graphtool: %v\n", err)
+		return 1
+	}
+	if *asJSON {
+		data, err := json.Marshal(result)
+		if err != nil {
+			fmt.Fprintf(stderr, "graphtool: %v\n", err)
+			return 1
+		}
+		fmt.Fprintln(stdout, string(data))
+		return 0
+	}
+	fmt.Fprint(stdout, text)
+	return 0
+}
+
+func usage(flags *flag.FlagSet) {
+	w := flags.Output()
+	fmt.Fprintln(w, "Usage: graphtool [flags] <command> <file> [arguments]")
+	fmt.Fprintln(w, "\nCommands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-28v %v\n", name+" <file> "+argNames(commands[name].args), commands[name].usage)
+	}
+	fmt.Fprintln(w, "\nFlags:")
+	flags.PrintDefaults()
+}
+
+func argNames(args []string) string {
+	names := []string{}
+	for _, a := range args {
+		names = append(names, "<"+a+">")
+	}
+	return strings.Join(names, " ")
+}
+
+// load reads a graph from a text or JSON file, or from stdin if file is -
+func load(file string, stdin io.Reader, directed bool) (*graph.Graph, error) {
+	r := stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	g := graph.NewGraph(directed)
+	if strings.HasSuffix(file, ".json") {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		return g, json.Unmarshal(data, g)
+	}
+	return g, g.Load(r)
+}
+
+// joinInts formats vertices as a space separated line
+func joinInts(vertices []int) string {
+	s := make([]string, len(vertices))
+	for i, v := range vertices {
+		s[i] = strconv.Itoa(v)
+	}
+	return strings.Join(s, " ") + "\n"
+}
+
+func findPath(g *graph.Graph, args []int) (interface{}, string, error) {
+	path, err := g.FindPath(args[0], args[1])
+	if err != nil {
+		return nil, "", err
+	}
+	return map[string][]int{"path": path}, joinInts(path), nil
+}
+
+func components(g *graph.Graph, args []int) (interface{}, string, error) {
+	list := [][]int{}
+	for _, vertices := range g.DynamicConnectivity().Components() {
+		list = append(list, vertices)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i][0] < list[j][0] })
+	text := ""
+	for _, vertices := range list {
+		text += joinInts(vertices)
+	}
+	return map[string][][]int{"components": list}, text, nil
+}
+
+func findCycle(g *graph.Graph, args []int) (interface{}, string, error) {
+	g.InitSearch()
+	for v := 1; v <= g.NumVertices(); v++ {
+		if g.State[v] != graph.UNDISCOVERED {
+			continue
+		}
+		if _, err := g.FindCycles(v); err == nil {
+			return map[string][]int{"cycle": g.Path}, joinInts(g.Path), nil
+		}
+	}
+	return map[string][]int{"cycle": nil}, "No cycle exists\n", nil
+}
+
+func articulation(g *graph.Graph, args []int) (interface{}, string, error) {
+	if g.Directed {
+		return nil, "", errors.New("Articulation vertices require an undirected graph")
+	}
+	g.InitSearch()
+	found := make(map[int]bool)
+	for v := 1; v <= g.NumVertices(); v++ {
+		if g.State[v] == graph.UNDISCOVERED {
+			for _, a := range g.FindArticulationVectors(v) {
+				found[a] = true
+			}
+		}
+	}
+	vertices := []int{}
+	for v := range found {
+		vertices = append(vertices, v)
+	}
+	sort.Ints(vertices)
+	return map[string][]int{"articulation": vertices}, joinInts(vertices), nil
+}
+
+func toposort(g *graph.Graph, args []int) (interface{}, string, error) {
+	order, err := g.TopologicalSort()
+	if err != nil {
+		return nil, "", err
+	}
+	return map[string][]int{"order": order}, joinInts(order), nil
+}
+
+func spanningTree(g *graph.Graph, args []int) (interface{}, string, error) {
+	edges, total, err := g.MinimumSpanningTree()
+	if err != nil {
+		return nil, "", err
+	}
+	text := ""
+	for _, e := range edges {
+		text += fmt.Sprintf("%v %v %v\n", e.X, e.Y, e.Weight)
+	}
+	text += fmt.Sprintf("Total weight: %v\n", total)
+	result := struct {
+		Edges  []graph.Edge `json:"edges"`
+		Weight int          `json:"weight"`
+	}{edges, total}
+	return result, text, nil
+}
+
+func dot(g *graph.Graph, args []int) (interface{}, string, error) {
+	var b strings.Builder
+	if err := g.WriteDOT(&b); err != nil {
+		return nil, "", err
+	}
+	return map[string]string{"dot": b.String()}, b.String(), nil
+}
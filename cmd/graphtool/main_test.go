@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const graph1 = "../../graph/test_data/graph1.txt"
+
+// runTool runs graphtool and returns its exit status, stdout and stderr
+func runTool(stdin string, args ...string) (int, string, string) {
+	stdout, stderr := bytes.NewBuffer([]byte{}), bytes.NewBuffer([]byte{})
+	status := run(args, strings.NewReader(stdin), stdout, stderr)
+	return status, stdout.String(), stderr.String()
+}
+
+func TestCommands(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"path", graph1, "1", "3"}, "1 2 3\n"},
+		{[]string{"-json", "path", graph1, "1", "3"}, `{"path":[1,2,3]}` + "\n"},
+		{[]string{"components", graph1}, "1 2 3 4 5 6\n7 8 9 10\n"},
+		{[]string{"-json", "components", graph1}, `{"components":[[1,2,3,4,5,6],[7,8,9,10]]}` + "\n"},
+		{[]string{"cycles", graph1}, "2 5 4 3\n"},
+		{[]string{"-directed", "cycles", graph1}, "2 3 4 5\n"},
+		{[]string{"articulation", graph1}, "1 2 8 9\n"},
+		{[]string{"-json", "articulation", graph1}, `{"articulation":[1,2,8,9]}` + "\n"},
+		{[]string{"mst", graph1}, "1 2 0\n1 6 0\n2 5 0\n2 3 0\n3 4 0\n7 8 0\n8 9 0\n9 10 0\nTotal weight: 0\n"},
+	}
+	for _, test := range tests {
+		status, out, errOut := runTool("", test.args...)
+		if status != 0 || out != test.want {
+			t.Errorf("%v: status %v, output %q, want %q (%v)", test.args, status, out, test.want, errOut)
+		}
+	}
+}
+
+func TestStdinAndJSONInput(t *testing.T) {
+	status, out, _ := runTool("3 1\n2 3\n4 2\n", "-directed", "toposort", "-")
+	if status != 0 || out != "4 2 3 1\n" {
+		t.Errorf("Incorrect topological order %q", out)
+	}
+	status, out, _ = runTool("1 2 3\n2 3 1\n1 3 5\n", "-json", "mst", "-")
+	if status != 0 || !strings.Contains(out, `"weight":4`) {
+		t.Errorf("Incorrect spanning tree %q", out)
+	}
+	status, out, _ = runTool("1 2 3\n", "dot", "-")
+	if status != 0 || out != "graph {\n\t1;\n\t2;\n\t1 -- 2 [label=\"3\"];\n}\n" {
+		t.Errorf("Incorrect DOT output %q", out)
+	}
+
+	file := filepath.Join(t.TempDir(), "g.json")
+	os.WriteFile(file, []byte(`{"directed":true,"vertices":3,"edges":[{"x":1,"y":2},{"x":2,"y":3},{"x":3,"y":1}]}`), 0644)
+	status, out, _ = runTool("", "cycles", file)
+	if status != 0 || out != "1 2 3\n" {
+		t.Errorf("Incorrect cycle %q from a JSON graph", out)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		args   []string
+		status int
+	}{
+		{[]string{}, 2},
+		{[]string{"-bogus", "path", graph1}, 2},
+		{[]string{"unknown", graph1}, 2},
+		{[]string{"path", graph1, "1"}, 2},
+		{[]string{"path", graph1, "1", "11"}, 1},
+		{[]string{"path", graph1, "1", "7"}, 1},
+		{[]string{"path", "missing.txt", "1", "2"}, 1},
+		{[]string{"-directed", "toposort", graph1}, 1},
+		{[]string{"toposort", graph1}, 1},
+		{[]string{"-directed", "mst", graph1}, 1},
+		{[]string{"-directed", "articulation", graph1}, 1},
+	}
+	for _, test := range tests {
+		status, _, errOut := runTool("", test.args...)
+		if status != test.status || errOut == "" {
+			t.Errorf("%v: status %v, want %v with a message", test.args, status, test.status)
+		}
+	}
+	if status, out, _ := runTool("1 2\n", "cycles", "-"); status != 0 || out != "No cycle exists\n" {
+		t.Errorf("Incorrect output %q for an acyclic graph", out)
+	}
+}
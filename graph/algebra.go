@@ -0,0 +1,223 @@
+package graph
+
+import "errors"
+
+// An Edge is a single edge of a graph, from X to Y for directed graphs
+type Edge struct {
+	ID         int        `json:"id"`
+	X          int        `json:"x"`
+	Y          int        `json:"y"`
+	Weight     int        `json:"weight"`
+	Attributes Attributes `json:"attributes,omitempty"`
+}
+
+// EdgeList returns every edge of the graph once, ordered by X and then by
+// adjacency list order. Undirected edges are reported with X <= Y.
+func (g *Graph) EdgeList() []Edge {
+	edges := []Edge{}
+	for x := 1; x <= g.nVertices; x++ {
+		selfLoops := 0
+		for edgeNode := g.Edges[x]; edgeNode != nil; edgeNode = edgeNode.Next {
+			if !g.Directed && edgeNode.Y < x {
+				continue
+			}
+			if !g.Directed && edgeNode.Y == x {
+				// Undirected self-loops are stored twice in the adjacency list
+				selfLoops++
+				if selfLoops%2 == 0 {
+					continue
+				}
+			}
+			edges = append(edges, Edge{ID: edgeNode.ID, X: x, Y: edgeNode.Y, Weight: edgeNode.Weight, Attributes: edgeNode.Attributes})
+		}
+	}
+	return edges
+}
+
+// fromEdges builds a graph with n vertices holding copies of the given edges.
+// Edges keep their ids unless an id is missing or repeated.
+func fromEdges(directed bool, n int, edges []Edge) *Graph {
+	h := NewGraph(directed)
+	h.InsertVertex(n)
+	// Inserting in reverse keeps adjacency lists in the order of the edges
+	for i := len(edges) - 1; i >= 0; i-- {
+		e := edges[i]
+		h.insertEdge(e.ID, e.X, e.Y, e.Weight, e.Attributes.clone(), directed)
+	}
+	return h
+}
+
+// Transpose returns a copy of the graph with every edge reversed. An
+// undirected graph is its own transpose so a plain copy is returned.
+func (g *Graph) Transpose() *Graph {
+	edges := g.EdgeList()
+	if g.Directed {
+		for i := range edges {
+			edges[i].X, edges[i].Y = edges[i].Y, edges[i].X
+		}
+	}
+	h := fromEdges(g.Directed, g.nVertices, edges)
+	h.simple = g.simple
+	h.copyVertexAttributes(g, sameVertex)
+	return h
+}
+
+// Complement returns the simple graph on the same vertices whose edges are
+// exactly the pairs of distinct vertices that are not adjacent in g
+func (g *Graph) Complement() *Graph {
+	adjacent := make(map[[2]int]bool)
+	for _, e := range g.EdgeList() {
+		adjacent[[2]int{e.X, e.Y}] = true
+	}
+	edges := []Edge{}
+	for x := 1; x <= g.nVertices; x++ {
+		y := x + 1
+		if g.Directed {
+			y = 1
+		}
+		for ; y <= g.nVertices; y++ {
+			if x != y && !adjacent[[2]int{x, y}] {
+				edges = append(edges, Edge{X: x, Y: y})
+			}
+		}
+	}
+	h := fromEdges(g.Directed, g.nVertices, edges)
+	h.simple = true
+	h.copyVertexAttributes(g, sameVertex)
+	return h
+}
+
+// Union returns a simple graph holding every edge found in either graph,
+// taking weights and attributes from g where both have them. Parallel edges
+// are merged and self-loops dropped.
+func (g *Graph) Union(other *Graph) (*Graph, error) {
+	if g.Directed != other.Directed {
+		return nil, errors.New("Cannot combine directed and undirected graphs")
+	}
+	seen := make(map[[2]int]bool)
+	edges := []Edge{}
+	for _, e := range append(g.EdgeList(), other.EdgeList()...) {
+		if e.X != e.Y && !seen[[2]int{e.X, e.Y}] {
+			seen[[2]int{e.X, e.Y}] = true
+			edges = append(edges, e)
+		}
+	}
+	n := g.nVertices
+	if other.nVertices > n {
+		n = other.nVertices
+	}
+	h := fromEdges(g.Directed, n, edges)
+	h.simple = true
+	h.copyVertexAttributes(other, sameVertex)
+	h.copyVertexAttributes(g, sameVertex)
+	return h, nil
+}
+
+// Intersection returns a simple graph on the vertices common to both graphs
+// holding the edges found in both, with weights and attributes taken from g.
+// Parallel edges are merged and self-loops dropped.
+func (g *Graph) Intersection(other *Graph) (*Graph, error) {
+	if g.Directed != other.Directed {
+		return nil, errors.New("Cannot combine directed and undirected graphs")
+	}
+	inOther := make(map[[2]int]bool)
+	for _, e := range other.EdgeList() {
+		inOther[[2]int{e.X, e.Y}] = true
+	}
+	seen := make(map[[2]int]bool)
+	edges := []Edge{}
+	for _, e := range g.EdgeList() {
+		if e.X != e.Y && inOther[[2]int{e.X, e.Y}] && !seen[[2]int{e.X, e.Y}] {
+			seen[[2]int{e.X, e.Y}] = true
+			edges = append(edges, e)
+		}
+	}
+	n := g.nVertices
+	if other.nVertices < n {
+		n = other.nVertices
+	}
+	h := fromEdges(g.Directed, n, edges)
+	h.simple = true
+	h.copyVertexAttributes(g, sameVertex)
+	return h, nil
+}
+
+// InducedSubgraph returns the graph made of the given vertices and every edge
+// between them. Vertices are renumbered 1..len(vertices) in the order given,
+// so vertex i of the subgraph is vertices[i-1] of g.
+func (g *Graph) InducedSubgraph(vertices []int) *Graph {
+	index := make(map[int]int)
+	for i, v := range vertices {
+		index[v] = i + 1
+	}
+	edges := []Edge{}
+	for _, e := range g.EdgeList() {
+		x, okX := index[e.X]
+		y, okY := index[e.Y]
+		if okX && okY {
+			edges = append(edges, Edge{ID: e.ID, X: x, Y: y, Weight: e.Weight, Attributes: e.Attributes})
+		}
+	}
+	h := fromEdges(g.Directed, len(vertices), edges)
+	h.simple = g.simple
+	h.copyVertexAttributes(g, func(v int) int { return vertices[v-1] })
+	return h
+}
+
+// EdgeSubgraph returns a graph on the same vertices keeping only the edges for
+// which keep returns true
+func (g *Graph) EdgeSubgraph(keep func(Edge) bool) *Graph {
+	edges := []Edge{}
+	for _, e := range g.EdgeList() {
+		if keep(e) {
+			edges = append(edges, e)
+		}
+	}
+	h := fromEdges(g.Directed, g.nVertices, edges)
+	h.simple = g.simple
+	h.copyVertexAttributes(g, sameVertex)
+	return h
+}
+
+// LineGraph returns the graph whose vertices are the edges of g, numbered in
+// EdgeList order and carrying the attributes of their edges. In an undirected
+// graph two edges are adjacent when they share an endpoint; in a directed
+// graph edge (u, v) points to every edge (v, w), so a directed self-loop
+// (v, v) points to itself as well.
+func (g *Graph) LineGraph() *Graph {
+	edges := g.EdgeList()
+	incident := make(map[int][]int) // line graph vertices touching each vertex of g
+	for i, e := range edges {
+		incident[e.X] = append(incident[e.X], i+1)
+		if !g.Directed && e.Y != e.X {
+			incident[e.Y] = append(incident[e.Y], i+1)
+		}
+	}
+	seen := make(map[[2]int]bool)
+	lineEdges := []Edge{}
+	for i, e := range edges {
+		from := i + 1
+		shared := incident[e.Y]
+		if !g.Directed {
+			shared = append(append([]int{}, incident[e.X]...), incident[e.Y]...)
+		}
+		for _, to := range shared {
+			key := [2]int{from, to}
+			if !g.Directed && to < from {
+				key = [2]int{to, from}
+			}
+			if to == from && !g.Directed || seen[key] {
+				continue
+			}
+			seen[key] = true
+			lineEdges = append(lineEdges, Edge{X: key[0], Y: key[1]})
+		}
+	}
+	h := fromEdges(g.Directed, len(edges), lineEdges)
+	for i, e := range edges {
+		if e.Attributes != nil {
+			h.vertexAttributes[i+1] = e.Attributes.clone()
+		}
+	}
+	return h
+}
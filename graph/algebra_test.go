@@ -0,0 +1,131 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEdgeList(t *testing.T) {
+	g := initGraph(false)
+	if len(g.EdgeList()) != g.NumEdges() {
+		t.Error("Undirected edges listed more than once")
+	}
+	g.InsertEdge(3, 3, false)
+	if len(g.EdgeList()) != g.NumEdges() {
+		t.Error("Undirected self-loop listed more than once")
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	g := initGraph(true)
+	tr := g.Transpose()
+	if tr.NumEdges() != g.NumEdges() || tr.NumVertices() != g.NumVertices() {
+		t.Error("Transpose changed the edge or vertex count")
+	}
+	if tr.Degree[1] != 0 || tr.Degree[2] != 2 {
+		t.Error("Transpose did not reverse edges")
+	}
+	if !reflect.DeepEqual(tr.Transpose().EdgeList(), g.EdgeList()) {
+		t.Error("Transposing twice did not restore the graph")
+	}
+}
+
+func TestComplement(t *testing.T) {
+	g := CycleGraph(5)
+	c := g.Complement()
+	if c.NumEdges() != 5 || c.Degree[1] != 2 {
+		t.Error("Complement of a 5-cycle should be a 5-cycle")
+	}
+	u, _ := g.Union(c)
+	if u.NumEdges() != CompleteGraph(5).NumEdges() {
+		t.Error("A graph and its complement should form a complete graph")
+	}
+	i, _ := g.Intersection(c)
+	if i.NumEdges() != 0 || i.NumVertices() != 5 {
+		t.Error("A graph and its complement should share no edges")
+	}
+	if _, err := g.Union(initGraph(true)); err == nil {
+		t.Error("Combined directed and undirected graphs")
+	}
+}
+
+func TestSetOperationsAreSimple(t *testing.T) {
+	g := NewGraph(false)
+	g.InsertEdge(1, 2, false)
+	g.InsertEdge(1, 2, false)
+	g.InsertEdge(3, 3, false)
+	u, _ := g.Union(g)
+	i, _ := g.Intersection(g)
+	if u.NumEdges() != 1 || i.NumEdges() != 1 {
+		t.Error("Union and intersection should merge parallel edges and drop self-loops")
+	}
+	for name, h := range map[string]*Graph{"Complement": g.Complement(), "Union": u, "Intersection": i} {
+		if !h.Simple() {
+			t.Errorf("%v should return a simple graph", name)
+		}
+		edges := h.NumEdges()
+		h.InsertEdge(1, 3, false)
+		h.InsertEdge(1, 3, false)
+		h.InsertEdge(2, 2, false)
+		if h.NumEdges() > edges+1 {
+			t.Errorf("%v accepted a parallel edge or self-loop", name)
+		}
+	}
+}
+
+func TestInducedSubgraph(t *testing.T) {
+	g := initGraph(false)
+	s := g.InducedSubgraph([]int{2, 3, 4, 5})
+	if s.NumVertices() != 4 || s.NumEdges() != 4 {
+		t.Error("Induced subgraph should be the 4-cycle 2-3-4-5")
+	}
+	for v := 1; v <= 4; v++ {
+		if s.Degree[v] != 2 {
+			t.Error("Incorrect degree in induced subgraph")
+		}
+	}
+}
+
+func TestEdgeSubgraph(t *testing.T) {
+	g := initGraph(true)
+	s := g.EdgeSubgraph(func(e Edge) bool { return e.X < 7 })
+	if s.NumEdges() != 6 || s.NumVertices() != g.NumVertices() {
+		t.Error("Edge subgraph kept the wrong edges")
+	}
+}
+
+func TestLineGraph(t *testing.T) {
+	// The line graph of a star is a complete graph
+	l := StarGraph(5).LineGraph()
+	if l.NumVertices() != 4 || l.NumEdges() != 6 {
+		t.Error("Line graph of a star should be complete")
+	}
+	// A directed path of three edges has a line graph that is a path of two
+	d := NewGraph(true)
+	d.InsertEdge(1, 2, true)
+	d.InsertEdge(2, 3, true)
+	d.InsertEdge(3, 4, true)
+	if d.LineGraph().NumEdges() != 2 {
+		t.Error("Incorrect directed line graph")
+	}
+	// A directed self-loop (2, 2) follows (1, 2), itself, and precedes (2, 3)
+	loop := NewGraph(true)
+	loop.InsertEdge(1, 2, true)
+	loop.InsertEdge(2, 2, true)
+	loop.InsertEdge(2, 3, true)
+	edges := loop.EdgeList()
+	id := make(map[[2]int]int)
+	for i, e := range edges {
+		id[[2]int{e.X, e.Y}] = i + 1
+	}
+	l = loop.LineGraph()
+	self := id[[2]int{2, 2}]
+	for _, arc := range [][2]int{{id[[2]int{1, 2}], self}, {self, self}, {self, id[[2]int{2, 3}]}, {id[[2]int{1, 2}], id[[2]int{2, 3}]}} {
+		if len(l.EdgeIDs(arc[0], arc[1])) != 1 {
+			t.Errorf("Line digraph is missing the arc %v", arc)
+		}
+	}
+	if l.NumEdges() != 4 {
+		t.Errorf("Line digraph has %d arcs, want 4", l.NumEdges())
+	}
+}
@@ -0,0 +1,82 @@
+package graph
+
+import "errors"
+
+// Hungarian solves the assignment problem: given cost[i][j] for giving job j
+// to worker i, it assigns every worker a distinct job so the total cost is as
+// small as possible. There may be more jobs than workers. It returns the job
+// of each worker and the total cost, in O(n^2 m) time for n workers and m
+// jobs.
+func Hungarian(cost [][]int) ([]int, int, error) {
+	n := len(cost)
+	if n == 0 {
+		return []int{}, 0, nil
+	}
+	m := len(cost[0])
+	for _, row := range cost {
+		if len(row) != m {
+			return nil, 0, errors.New("Cost matrix rows must have equal length")
+		}
+	}
+	if n > m {
+		return nil, 0, errors.New("Cannot assign more workers than jobs")
+	}
+
+	// Potentials u (workers) and v (jobs) keep cost[i][j] - u[i] - v[j] >= 0.
+	// Workers and jobs are 1-indexed here; job 0 is a sentinel.
+	u := make([]int, n+1)
+	v := make([]int, m+1)
+	worker := make([]int, m+1) // worker assigned to each job, 0 if none
+	way := make([]int, m+1)    // previous job on the alternating path
+	for i := 1; i <= n; i++ {
+		worker[0] = i
+		j0 := 0
+		minSlack := make([]int, m+1)
+		used := make([]bool, m+1)
+		for j := range minSlack {
+			minSlack[j] = maxCapacity
+		}
+		for worker[j0] != 0 {
+			used[j0] = true
+			i0, delta, j1 := worker[j0], maxCapacity, 0
+			for j := 1; j <= m; j++ {
+				if used[j] {
+					continue
+				}
+				if slack := cost[i0-1][j-1] - u[i0] - v[j]; slack < minSlack[j] {
+					minSlack[j] = slack
+					way[j] = j0
+				}
+				if minSlack[j] < delta {
+					delta = minSlack[j]
+					j1 = j
+				}
+			}
+			for j := 0; j <= m; j++ {
+				if used[j] {
+					u[worker[j]] += delta
+					v[j] -= delta
+				} else {
+					minSlack[j] -= delta
+				}
+			}
+			j0 = j1
+		}
+		// Flip the alternating path to take in the new worker
+		for j0 != 0 {
+			j1 := way[j0]
+			worker[j0] = worker[j1]
+			j0 = j1
+		}
+	}
+
+	assignment := make([]int, n)
+	total := 0
+	for j := 1; j <= m; j++ {
+		if worker[j] != 0 {
+			assignment[worker[j]-1] = j - 1
+			total += cost[worker[j]-1][j-1]
+		}
+	}
+	return assignment, total, nil
+}
@@ -0,0 +1,51 @@
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestHungarian(t *testing.T) {
+	cost := [][]int{
+		{4, 1, 3},
+		{2, 0, 5},
+		{3, 2, 2},
+	}
+	assignment, total, err := Hungarian(cost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(assignment, []int{1, 0, 2}) || total != 5 {
+		t.Errorf("Incorrect assignment %v with cost %v", assignment, total)
+	}
+	if _, _, err := Hungarian([][]int{{1}, {2}}); err == nil {
+		t.Error("Assigned more workers than jobs")
+	}
+}
+
+func TestHungarianMatchesMinCostFlow(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	for trial := 0; trial < 20; trial++ {
+		n, m := 4, 6
+		cost := make([][]int, n)
+		f := NewFlowNetwork(n + m + 2)
+		source, sink := n+m+1, n+m+2
+		for i := range cost {
+			cost[i] = make([]int, m)
+			f.AddEdge(source, i+1, 1, 0)
+			for j := range cost[i] {
+				cost[i][j] = r.Intn(20)
+				f.AddEdge(i+1, n+j+1, 1, cost[i][j])
+			}
+		}
+		for j := 0; j < m; j++ {
+			f.AddEdge(n+j+1, sink, 1, 0)
+		}
+		_, total, _ := Hungarian(cost)
+		_, expected, _ := f.MinCostMaxFlow(source, sink)
+		if total != expected {
+			t.Fatalf("Hungarian cost %v differs from min cost flow %v", total, expected)
+		}
+	}
+}
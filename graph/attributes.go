@@ -0,0 +1,90 @@
+package graph
+
+import "errors"
+
+// Attributes hold the labels and other metadata of a vertex or an edge as
+// key/value pairs
+type Attributes map[string]string
+
+// clone returns a copy of the attributes that can be changed independently
+func (a Attributes) clone() Attributes {
+	if a == nil {
+		return nil
+	}
+	c := make(Attributes, len(a))
+	for k, v := range a {
+		c[k] = v
+	}
+	return c
+}
+
+// SetVertexAttribute stores a key/value pair on vertex v, adding the vertex
+// to the graph if needed
+func (g *Graph) SetVertexAttribute(v int, key, value string) {
+	if g.vertexAttributes == nil {
+		g.vertexAttributes = make(map[int]Attributes)
+	}
+	if g.vertexAttributes[v] == nil {
+		g.vertexAttributes[v] = make(Attributes)
+	}
+	g.vertexAttributes[v][key] = value
+	g.trackVertex(v)
+}
+
+// VertexAttribute returns the value stored under key on vertex v
+func (g *Graph) VertexAttribute(v int, key string) (string, bool) {
+	value, ok := g.vertexAttributes[v][key]
+	return value, ok
+}
+
+// VertexAttributes returns the attributes of vertex v, or nil if it has none
+func (g *Graph) VertexAttributes(v int) Attributes {
+	return g.vertexAttributes[v]
+}
+
+// SetEdgeAttribute stores a key/value pair on the first edge from x to y in
+// adjacency list order. For undirected graphs the value is visible from both
+// endpoints. Use SetEdgeAttributeByID to address a particular parallel edge.
+func (g *Graph) SetEdgeAttribute(x, y int, key, value string) error {
+	edgeNode := g.findEdge(x, y)
+	if edgeNode == nil {
+		return errors.New("Edge does not exist")
+	}
+	return g.SetEdgeAttributeByID(edgeNode.ID, key, value)
+}
+
+// EdgeAttribute returns the value stored under key on the first edge from x
+// to y
+func (g *Graph) EdgeAttribute(x, y int, key string) (string, bool) {
+	edgeNode := g.findEdge(x, y)
+	if edgeNode == nil {
+		return "", false
+	}
+	value, ok := edgeNode.Attributes[key]
+	return value, ok
+}
+
+// findEdge returns the first adjacency list entry for an edge from x to y
+func (g *Graph) findEdge(x, y int) *EdgeNode {
+	for edgeNode := g.Edges[x]; edgeNode != nil; edgeNode = edgeNode.Next {
+		if edgeNode.Y == y {
+			return edgeNode
+		}
+	}
+	return nil
+}
+
+// copyVertexAttributes gives each vertex of g a copy of the attributes of a
+// vertex of from. vertexOf maps vertices of g to vertices of from.
+func (g *Graph) copyVertexAttributes(from *Graph, vertexOf func(v int) int) {
+	for v := 1; v <= g.nVertices; v++ {
+		if a := from.vertexAttributes[vertexOf(v)]; a != nil {
+			g.vertexAttributes[v] = a.clone()
+		}
+	}
+}
+
+// sameVertex maps every vertex to itself, for copyVertexAttributes
+func sameVertex(v int) int {
+	return v
+}
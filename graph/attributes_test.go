@@ -0,0 +1,115 @@
+package graph
+
+import "testing"
+
+func labelledGraph() *Graph {
+	g := initGraph(false)
+	for v := 1; v <= g.NumVertices(); v++ {
+		g.SetVertexAttribute(v, "name", string(rune('a'+v-1)))
+	}
+	g.SetEdgeAttribute(2, 3, "color", "red")
+	g.InsertEdgeWithAttributes(3, 5, 4, Attributes{"color": "blue"}, false)
+	return g
+}
+
+func TestVertexAttributes(t *testing.T) {
+	g := labelledGraph()
+	if name, ok := g.VertexAttribute(4, "name"); !ok || name != "d" {
+		t.Errorf("Incorrect name of vertex 4: %v", name)
+	}
+	if _, ok := g.VertexAttribute(4, "missing"); ok {
+		t.Error("Found a missing attribute")
+	}
+	g.SetVertexAttribute(12, "name", "l")
+	if g.NumVertices() != 12 || g.VertexAttributes(11) != nil {
+		t.Error("Setting an attribute should add the vertex")
+	}
+}
+
+func TestEdgeAttributes(t *testing.T) {
+	g := labelledGraph()
+	for _, e := range [][2]int{{2, 3}, {3, 2}} {
+		if c, ok := g.EdgeAttribute(e[0], e[1], "color"); !ok || c != "red" {
+			t.Errorf("Edge %v -> %v should be red", e[0], e[1])
+		}
+	}
+	if c, _ := g.EdgeAttribute(5, 3, "color"); c != "blue" {
+		t.Error("Inserted attributes should be visible from both endpoints")
+	}
+	if err := g.SetEdgeAttribute(1, 3, "color", "green"); err == nil {
+		t.Error("Set an attribute on a missing edge")
+	}
+
+	d := initGraph(true)
+	d.SetEdgeAttribute(2, 3, "color", "red")
+	if _, ok := d.EdgeAttribute(3, 2, "color"); ok {
+		t.Error("Directed edges should not share attributes with their reverse")
+	}
+}
+
+func TestAttributesSurviveTraversal(t *testing.T) {
+	g := labelledGraph()
+	g.InitSearch()
+	g.BreadthFirstSearch(1)
+	red := 0
+	for x := 1; x <= g.NumVertices(); x++ {
+		for edgeNode := g.Edges[x]; edgeNode != nil; edgeNode = edgeNode.Next {
+			if edgeNode.Attributes["color"] == "red" {
+				red++
+			}
+		}
+	}
+	if red != 2 {
+		t.Errorf("Expected the red edge in both adjacency lists, found %v", red)
+	}
+	for _, e := range g.EdgeList() {
+		if (e.X == 2 && e.Y == 3) != (e.Attributes["color"] == "red") {
+			t.Errorf("EdgeList has the wrong attributes on %v -> %v", e.X, e.Y)
+		}
+	}
+}
+
+func TestAttributesSurviveSubgraphs(t *testing.T) {
+	g := labelledGraph()
+	s := g.InducedSubgraph([]int{3, 2, 5})
+	if name, _ := s.VertexAttribute(1, "name"); name != "c" {
+		t.Errorf("Vertex 1 of the subgraph should be c, got %v", name)
+	}
+	if c, _ := s.EdgeAttribute(1, 2, "color"); c != "red" {
+		t.Error("Induced subgraph lost an edge attribute")
+	}
+	if c, _ := s.EdgeAttribute(3, 1, "color"); c != "blue" {
+		t.Error("Induced subgraph lost an edge attribute")
+	}
+
+	// Derived graphs own their attributes
+	s.SetVertexAttribute(1, "name", "changed")
+	s.SetEdgeAttribute(1, 2, "color", "changed")
+	if name, _ := g.VertexAttribute(3, "name"); name != "c" {
+		t.Error("Changing a subgraph changed the original vertex")
+	}
+	if c, _ := g.EdgeAttribute(2, 3, "color"); c != "red" {
+		t.Error("Changing a subgraph changed the original edge")
+	}
+
+	e := g.EdgeSubgraph(func(e Edge) bool { return e.Attributes["color"] != "" })
+	if e.NumEdges() != 2 {
+		t.Errorf("Expected the two coloured edges, found %v", e.NumEdges())
+	}
+	if name, _ := e.VertexAttribute(10, "name"); name != "j" {
+		t.Error("Edge subgraph lost a vertex attribute")
+	}
+	if name, _ := g.Transpose().VertexAttribute(7, "name"); name != "g" {
+		t.Error("Transpose lost a vertex attribute")
+	}
+	line := g.LineGraph()
+	found := false
+	for v := 1; v <= line.NumVertices(); v++ {
+		if c, _ := line.VertexAttribute(v, "color"); c == "blue" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Line graph vertices should carry edge attributes")
+	}
+}
@@ -0,0 +1,139 @@
+package graph
+
+import "errors"
+
+// bitset is a fixed size set of small non-negative integers
+type bitset []uint64
+
+func newBitset(n int) bitset {
+	return make(bitset, (n+64)/64)
+}
+
+func (b bitset) set(i int) {
+	b[i/64] |= 1 << uint(i%64)
+}
+
+func (b bitset) has(i int) bool {
+	return b[i/64]&(1<<uint(i%64)) != 0
+}
+
+func (b bitset) or(other bitset) {
+	for i := range b {
+		b[i] |= other[i]
+	}
+}
+
+// Reachability answers whether one vertex can reach another. Each vertex keeps
+// a bitset of the vertices it reaches, so queries take constant time.
+type Reachability struct {
+	directed bool
+	reach    []bitset // vertices reachable from each vertex by a walk of one or more edges
+}
+
+// Reachability computes the reachability of every vertex. Vertices in the same
+// strongly connected component reach the same vertices, so each component is
+// handled once, in reverse topological order, combining the sets of the
+// components its edges lead to.
+func (g *Graph) Reachability() *Reachability {
+	n := g.nVertices
+	components := g.StronglyConnectedComponents()
+	componentOf := make(map[int]int)
+	for id, members := range components {
+		for _, v := range members {
+			componentOf[v] = id
+		}
+	}
+	componentReach := make([]bitset, len(components)+1)
+	// Components are numbered sinks first so successors are always ready
+	for id := 1; id <= len(components); id++ {
+		componentReach[id] = newBitset(n)
+		for _, v := range components[id] {
+			for edgeNode := g.Edges[v]; edgeNode != nil; edgeNode = edgeNode.Next {
+				componentReach[id].set(edgeNode.Y)
+				if c := componentOf[edgeNode.Y]; c != id {
+					componentReach[id].or(componentReach[c])
+				}
+			}
+		}
+	}
+	r := &Reachability{directed: g.Directed, reach: make([]bitset, n+1)}
+	for v := 1; v <= n; v++ {
+		r.reach[v] = componentReach[componentOf[v]]
+	}
+	return r
+}
+
+// Reachable reports whether a walk of one or more edges leads from x to y
+func (r *Reachability) Reachable(x, y int) bool {
+	if x < 1 || x >= len(r.reach) || y < 1 || y >= len(r.reach) {
+		return false
+	}
+	return r.reach[x].has(y)
+}
+
+// Graph returns the transitive closure as a graph with an edge from x to y
+// whenever x reaches y
+func (r *Reachability) Graph() *Graph {
+	n := len(r.reach) - 1
+	edges := []Edge{}
+	for x := 1; x <= n; x++ {
+		y := 1
+		if !r.directed {
+			y = x
+		}
+		for ; y <= n; y++ {
+			if r.reach[x].has(y) {
+				edges = append(edges, Edge{X: x, Y: y})
+			}
+		}
+	}
+	return fromEdges(r.directed, n, edges)
+}
+
+// TransitiveClosure returns the graph with an edge from x to y whenever a walk
+// of one or more edges leads from x to y in g
+func (g *Graph) TransitiveClosure() *Graph {
+	return g.Reachability().Graph()
+}
+
+// TransitiveReduction returns the smallest graph with the same reachability
+// as a directed acyclic graph: an edge from x to y is kept only when y cannot
+// be reached through another successor of x. Parallel edges are merged.
+func (g *Graph) TransitiveReduction() (*Graph, error) {
+	if !g.Directed {
+		return nil, errors.New("Transitive reduction requires a directed graph")
+	}
+	for _, members := range g.StronglyConnectedComponents() {
+		if len(members) > 1 {
+			return nil, errors.New("Transitive reduction requires an acyclic graph")
+		}
+	}
+	r := g.Reachability()
+	edges := []Edge{}
+	for _, e := range g.EdgeList() {
+		if e.X == e.Y {
+			return nil, errors.New("Transitive reduction requires an acyclic graph")
+		}
+		redundant := false
+		for edgeNode := g.Edges[e.X]; edgeNode != nil && !redundant; edgeNode = edgeNode.Next {
+			redundant = edgeNode.Y != e.Y && r.Reachable(edgeNode.Y, e.Y)
+		}
+		if !redundant && !containsEdge(edges, e.X, e.Y) {
+			edges = append(edges, e)
+		}
+	}
+	h := fromEdges(true, g.nVertices, edges)
+	h.copyVertexAttributes(g, sameVertex)
+	return h, nil
+}
+
+// containsEdge reports whether the list already holds an edge from x to y,
+// scanning back only over the edges that share x
+func containsEdge(edges []Edge, x, y int) bool {
+	for i := len(edges) - 1; i >= 0 && edges[i].X == x; i-- {
+		if edges[i].Y == y {
+			return true
+		}
+	}
+	return false
+}
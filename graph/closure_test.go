@@ -0,0 +1,74 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestReachability(t *testing.T) {
+	g := initGraph(true)
+	r := g.Reachability()
+	for _, c := range []struct {
+		x, y      int
+		reachable bool
+	}{{1, 5, true}, {5, 3, true}, {3, 3, true}, {1, 1, false}, {6, 1, false}, {7, 10, true}, {1, 7, false}} {
+		if r.Reachable(c.x, c.y) != c.reachable {
+			t.Errorf("Reachable(%v, %v) should be %v", c.x, c.y, c.reachable)
+		}
+	}
+
+	// Every reachable pair must agree with a plain search
+	for x := 1; x <= g.NumVertices(); x++ {
+		for y := 1; y <= g.NumVertices(); y++ {
+			_, err := g.FindPath(x, y)
+			if x != y && (err == nil) != r.Reachable(x, y) {
+				t.Errorf("Reachability of %v from %v disagrees with FindPath", y, x)
+			}
+		}
+	}
+}
+
+func TestTransitiveClosure(t *testing.T) {
+	c := PathGraph(4).TransitiveClosure()
+	if c.NumEdges() != 10 { // K4 plus a self-loop walk at every vertex
+		t.Errorf("Incorrect closure of an undirected path: %v edges", c.NumEdges())
+	}
+	d := initGraph(true).TransitiveClosure()
+	if d.Degree[1] != 5 || d.Degree[2] != 4 || d.Degree[6] != 0 {
+		t.Error("Incorrect closure of graph1")
+	}
+}
+
+func TestTransitiveReduction(t *testing.T) {
+	// A random DAG: edges only go from lower to higher vertices
+	r := rand.New(rand.NewSource(11))
+	g := NewGraph(true)
+	for i := 0; i < 60; i++ {
+		x, y := r.Intn(15)+1, r.Intn(15)+1
+		if x < y {
+			g.InsertEdge(x, y, true)
+		}
+	}
+	reduced, err := g.TransitiveReduction()
+	if err != nil {
+		t.Fatal(err)
+	}
+	full, small := g.Reachability(), reduced.Reachability()
+	for x := 1; x <= g.NumVertices(); x++ {
+		for y := 1; y <= g.NumVertices(); y++ {
+			if full.Reachable(x, y) != small.Reachable(x, y) {
+				t.Fatal("Reduction changed reachability")
+			}
+		}
+	}
+	for _, e := range reduced.EdgeList() {
+		without := reduced.EdgeSubgraph(func(f Edge) bool { return f.X != e.X || f.Y != e.Y })
+		if without.Reachability().Reachable(e.X, e.Y) {
+			t.Errorf("Edge %v -> %v is redundant", e.X, e.Y)
+		}
+	}
+
+	if _, err := initGraph(true).TransitiveReduction(); err == nil {
+		t.Error("Reduced a cyclic graph")
+	}
+}
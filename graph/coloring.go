@@ -0,0 +1,278 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Enum of vertex orderings used by greedy coloring (i.e LARGEST_FIRST, SMALLEST_LAST, DSATUR)
+type ColoringOrder int
+
+const (
+	LARGEST_FIRST = 1 + iota
+	SMALLEST_LAST
+	DSATUR
+)
+
+var coloringOrders = [...]string{
+	"LARGEST_FIRST",
+	"SMALLEST_LAST",
+	"DSATUR",
+}
+
+// String for the ColoringOrder enables this enum to appear as a string when passed to fmt
+func (order ColoringOrder) String() string {
+	return coloringOrders[order-1]
+}
+
+// GreedyColoring assigns each vertex the smallest color (starting at 1) not
+// used by any of its neighbours, visiting vertices in the given order.
+// Edge direction is ignored. It returns a map of vertex to color.
+func (g *Graph) GreedyColoring(order ColoringOrder) map[int]int {
+	adj := g.undirectedNeighbors()
+	if order == DSATUR {
+		return dsaturColoring(adj, g.nVertices)
+	}
+
+	var vertices []int
+	if order == SMALLEST_LAST {
+		vertices = smallestLastOrder(adj, g.nVertices)
+	} else {
+		vertices = largestFirstOrder(adj, g.nVertices)
+	}
+	coloring := make(map[int]int)
+	for _, v := range vertices {
+		coloring[v] = smallestFreeColor(adj[v], coloring)
+	}
+	return coloring
+}
+
+// largestFirstOrder sorts the vertices by decreasing degree
+func largestFirstOrder(adj map[int]map[int]bool, n int) []int {
+	vertices := make([]int, 0, n)
+	for i := 1; i <= n; i++ {
+		vertices = append(vertices, i)
+	}
+	sort.SliceStable(vertices, func(i, j int) bool {
+		return len(adj[vertices[i]]) > len(adj[vertices[j]])
+	})
+	return vertices
+}
+
+// smallestLastOrder repeatedly removes a vertex of minimum remaining degree
+// and returns the vertices in the reverse order of removal
+func smallestLastOrder(adj map[int]map[int]bool, n int) []int {
+	degree := make(map[int]int)
+	for i := 1; i <= n; i++ {
+		degree[i] = len(adj[i])
+	}
+	removed := make(map[int]bool)
+	order := make([]int, n)
+	for k := n - 1; k >= 0; k-- {
+		min := -1
+		for i := 1; i <= n; i++ {
+			if !removed[i] && (min == -1 || degree[i] < degree[min]) {
+				min = i
+			}
+		}
+		removed[min] = true
+		order[k] = min
+		for y := range adj[min] {
+			degree[y]--
+		}
+	}
+	return order
+}
+
+// dsaturColoring colors next the vertex whose neighbours already use the most
+// distinct colors, breaking ties by degree
+func dsaturColoring(adj map[int]map[int]bool, n int) map[int]int {
+	coloring := make(map[int]int)
+	saturation := make(map[int]map[int]bool)
+	for i := 1; i <= n; i++ {
+		saturation[i] = make(map[int]bool)
+	}
+	for len(coloring) < n {
+		next := -1
+		for i := 1; i <= n; i++ {
+			if _, ok := coloring[i]; ok {
+				continue
+			}
+			if next == -1 || len(saturation[i]) > len(saturation[next]) ||
+				(len(saturation[i]) == len(saturation[next]) && len(adj[i]) > len(adj[next])) {
+				next = i
+			}
+		}
+		c := smallestFreeColor(adj[next], coloring)
+		coloring[next] = c
+		for y := range adj[next] {
+			saturation[y][c] = true
+		}
+	}
+	return coloring
+}
+
+// smallestFreeColor returns the lowest color not used by any of the neighbours
+func smallestFreeColor(neighbors map[int]bool, coloring map[int]int) int {
+	used := make(map[int]bool)
+	for y := range neighbors {
+		used[coloring[y]] = true
+	}
+	c := 1
+	for used[c] {
+		c++
+	}
+	return c
+}
+
+// ExactColoring finds a coloring using the minimum number of colors by
+// backtracking. The search is exponential so it is meant for small graphs;
+// if ctx is done before the search completes, the best coloring found so far
+// is returned along with the context's error.
+func (g *Graph) ExactColoring(ctx context.Context) (map[int]int, error) {
+	for x, edgeNode := range g.Edges {
+		for ; edgeNode != nil; edgeNode = edgeNode.Next {
+			if x == edgeNode.Y {
+				return nil, errors.New("Graph with a self-loop cannot be colored")
+			}
+		}
+	}
+	adj := g.undirectedNeighbors()
+	best := dsaturColoring(adj, g.nVertices)
+	c := &exactColorer{
+		ctx:      ctx,
+		adj:      adj,
+		order:    largestFirstOrder(adj, g.nVertices),
+		coloring: make(map[int]int),
+		best:     best,
+		nBest:    numColors(best),
+	}
+	c.search(0, 0)
+	return c.best, c.err
+}
+
+// exactColorer holds the state of the branch and bound coloring search
+type exactColorer struct {
+	ctx      context.Context
+	adj      map[int]map[int]bool
+	order    []int
+	coloring map[int]int
+	best     map[int]int
+	nBest    int
+	err      error
+}
+
+func (c *exactColorer) search(i, used int) {
+	if c.err != nil {
+		return
+	}
+	if err := c.ctx.Err(); err != nil {
+		c.err = err
+		return
+	}
+	if i == len(c.order) {
+		c.best = make(map[int]int)
+		for v, color := range c.coloring {
+			c.best[v] = color
+		}
+		c.nBest = used
+		return
+	}
+	v := c.order[i]
+	// Only try colors that could improve on the best coloring found so far
+	for color := 1; color <= used+1 && color < c.nBest; color++ {
+		if c.conflicts(v, color) {
+			continue
+		}
+		c.coloring[v] = color
+		if color > used {
+			c.search(i+1, color)
+		} else {
+			c.search(i+1, used)
+		}
+		delete(c.coloring, v)
+	}
+}
+
+func (c *exactColorer) conflicts(v, color int) bool {
+	for y := range c.adj[v] {
+		if c.coloring[y] == color {
+			return true
+		}
+	}
+	return false
+}
+
+// ChromaticNumber returns the minimum number of colors needed to color the graph
+func (g *Graph) ChromaticNumber(ctx context.Context) (int, error) {
+	coloring, err := g.ExactColoring(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return numColors(coloring), nil
+}
+
+// ChromaticBounds returns cheap lower and upper bounds on the chromatic number.
+// The lower bound is the size of a greedily grown clique and the upper bound is
+// the fewest colors used by any of the greedy orderings.
+func (g *Graph) ChromaticBounds() (int, int) {
+	adj := g.undirectedNeighbors()
+	lower := 0
+	for _, v := range largestFirstOrder(adj, g.nVertices) {
+		clique := []int{v}
+		for _, y := range largestFirstOrder(adj, g.nVertices) {
+			if y != v && adjacentToAll(adj, y, clique) {
+				clique = append(clique, y)
+			}
+		}
+		if len(clique) > lower {
+			lower = len(clique)
+		}
+	}
+	upper := 0
+	for _, order := range []ColoringOrder{LARGEST_FIRST, SMALLEST_LAST, DSATUR} {
+		n := numColors(g.GreedyColoring(order))
+		if upper == 0 || n < upper {
+			upper = n
+		}
+	}
+	return lower, upper
+}
+
+func adjacentToAll(adj map[int]map[int]bool, v int, vertices []int) bool {
+	for _, u := range vertices {
+		if !adj[v][u] {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateColoring checks that every vertex is colored and that no edge joins
+// two vertices of the same color
+func (g *Graph) ValidateColoring(coloring map[int]int) error {
+	for i := 1; i <= g.nVertices; i++ {
+		if coloring[i] < 1 {
+			return fmt.Errorf("Vertex %v is not colored", i)
+		}
+	}
+	for x, edgeNode := range g.Edges {
+		for ; edgeNode != nil; edgeNode = edgeNode.Next {
+			if coloring[x] == coloring[edgeNode.Y] {
+				return fmt.Errorf("Edge %v -> %v joins two vertices of color %v", x, edgeNode.Y, coloring[x])
+			}
+		}
+	}
+	return nil
+}
+
+// numColors counts the distinct colors used by a coloring
+func numColors(coloring map[int]int) int {
+	colors := make(map[int]bool)
+	for _, c := range coloring {
+		colors[c] = true
+	}
+	return len(colors)
+}
@@ -0,0 +1,86 @@
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGreedyColoring(t *testing.T) {
+	g := initGraph(false)
+	for _, order := range []ColoringOrder{LARGEST_FIRST, SMALLEST_LAST, DSATUR} {
+		coloring := g.GreedyColoring(order)
+		if err := g.ValidateColoring(coloring); err != nil {
+			t.Errorf("%v produced an improper coloring: %v", order, err)
+		}
+	}
+}
+
+func TestExactColoring(t *testing.T) {
+	// An odd cycle needs three colors
+	g := NewGraph(false)
+	for i := 1; i <= 5; i++ {
+		g.InsertEdge(i, i%5+1, false)
+	}
+	coloring, err := g.ExactColoring(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.ValidateColoring(coloring); err != nil {
+		t.Error(err)
+	}
+	if numColors(coloring) != 3 {
+		t.Error("Incorrect chromatic number for an odd cycle")
+	}
+
+	// The Read graph is a forest plus a cycle of length 4, so two colors suffice
+	n, err := initGraph(false).ChromaticNumber(context.Background())
+	if err != nil || n != 2 {
+		t.Error("Incorrect chromatic number for graph1")
+	}
+}
+
+func TestExactColoringTimeout(t *testing.T) {
+	g := NewGraph(false)
+	for i := 1; i <= 60; i++ {
+		for j := i + 1; j <= 60; j++ {
+			if (i*j)%7 != 0 {
+				g.InsertEdge(i, j, false)
+			}
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+	coloring, err := g.ExactColoring(ctx)
+	if err == nil {
+		t.Error("Expected the search to be interrupted")
+	}
+	if g.ValidateColoring(coloring) != nil {
+		t.Error("Interrupted search did not return a proper coloring")
+	}
+}
+
+func TestChromaticBounds(t *testing.T) {
+	g := NewGraph(false)
+	for i := 1; i <= 4; i++ {
+		for j := i + 1; j <= 4; j++ {
+			g.InsertEdge(i, j, false)
+		}
+	}
+	lower, upper := g.ChromaticBounds()
+	if lower != 4 || upper != 4 {
+		t.Error("Incorrect chromatic bounds for a complete graph")
+	}
+}
+
+func TestValidateColoring(t *testing.T) {
+	g := NewGraph(true)
+	g.InsertEdge(1, 2, true)
+	if g.ValidateColoring(map[int]int{1: 1, 2: 1}) == nil {
+		t.Error("Accepted an improper coloring")
+	}
+	if g.ValidateColoring(map[int]int{1: 1}) == nil {
+		t.Error("Accepted a partial coloring")
+	}
+}
@@ -0,0 +1,211 @@
+package graph
+
+import "sort"
+
+// DynamicConnectivity keeps the connected components of a graph up to date
+// while edges are added and removed through it, answering Connected queries
+// in constant time. Edge direction is ignored, so directed graphs get their
+// weakly connected components.
+//
+// Components are a union-find with explicit labels: adding an edge between two
+// components relabels the smaller one. A spanning forest is kept alongside.
+// Removing an edge outside the forest costs nothing. Removing a forest edge
+// searches the smaller of the two halves it leaves for a replacement edge and
+// splits the component if there is none, so the work is proportional to the
+// smaller half.
+//
+// Change the graph only through the structure once it is created; edges
+// inserted into the graph directly are not seen.
+type DynamicConnectivity struct {
+	g             *Graph
+	component     []int          // Component id of each vertex
+	size          map[int]int    // Number of vertices in each component
+	incident      []map[int]bool // Edge ids at each vertex, true for spanning forest edges
+	lastComponent int            // Largest component id handed out so far
+}
+
+// DynamicConnectivity computes the components of the graph and returns a
+// structure that maintains them as the graph changes
+func (g *Graph) DynamicConnectivity() *DynamicConnectivity {
+	d := &DynamicConnectivity{g: g, size: make(map[int]int)}
+	d.grow()
+	ids := make([]int, 0, len(g.edgeEnds))
+	for id := range g.edgeEnds {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		d.link(id)
+	}
+	return d
+}
+
+// grow gives every vertex added to the graph since the last call a component
+// of its own
+func (d *DynamicConnectivity) grow() {
+	for v := len(d.component); v <= d.g.nVertices; v++ {
+		d.component = append(d.component, 0)
+		d.incident = append(d.incident, make(map[int]bool))
+		if v > 0 {
+			d.lastComponent++
+			d.component[v] = d.lastComponent
+			d.size[d.lastComponent] = 1
+		}
+	}
+}
+
+// AddEdge inserts an edge from x to y into the graph, merging the components
+// of x and y, and returns its id
+func (d *DynamicConnectivity) AddEdge(x, y, weight int) (int, error) {
+	id, err := d.g.AddEdge(x, y, weight)
+	if err != nil {
+		return 0, err
+	}
+	d.grow()
+	d.link(id)
+	return id, nil
+}
+
+// RemoveEdge deletes the edge with the given id from the graph, splitting its
+// component if no other path joins the endpoints
+func (d *DynamicConnectivity) RemoveEdge(id int) error {
+	ends := d.g.edgeEnds[id]
+	if err := d.g.RemoveEdge(id); err != nil {
+		return err
+	}
+	x, y := ends[0], ends[1]
+	tree := d.incident[x][id]
+	delete(d.incident[x], id)
+	delete(d.incident[y], id)
+	if tree {
+		d.cut(x, y)
+	}
+	return nil
+}
+
+// Connected reports whether a path joins x and y
+func (d *DynamicConnectivity) Connected(x, y int) bool {
+	d.grow()
+	return d.Component(x) != 0 && d.Component(x) == d.Component(y)
+}
+
+// Component returns the id of the component holding v, or 0 if v is not a
+// vertex. Ids stay the same until the component is merged or split.
+func (d *DynamicConnectivity) Component(v int) int {
+	d.grow()
+	if v < 1 || v >= len(d.component) {
+		return 0
+	}
+	return d.component[v]
+}
+
+// NumComponents returns the number of connected components
+func (d *DynamicConnectivity) NumComponents() int {
+	d.grow()
+	return len(d.size)
+}
+
+// Components returns the vertices of every component in increasing order,
+// keyed by component id
+func (d *DynamicConnectivity) Components() map[int][]int {
+	d.grow()
+	components := make(map[int][]int)
+	for v := 1; v < len(d.component); v++ {
+		components[d.component[v]] = append(components[d.component[v]], v)
+	}
+	return components
+}
+
+// link records a new edge, adding it to the spanning forest if it joins two
+// components
+func (d *DynamicConnectivity) link(id int) {
+	ends := d.g.edgeEnds[id]
+	x, y := ends[0], ends[1]
+	cx, cy := d.component[x], d.component[y]
+	tree := cx != cy
+	d.incident[x][id] = tree
+	d.incident[y][id] = tree
+	if !tree {
+		return
+	}
+	if d.size[cx] < d.size[cy] {
+		y, cx, cy = x, cy, cx
+	}
+	d.relabel(y, cy, cx)
+	d.size[cx] += d.size[cy]
+	delete(d.size, cy)
+}
+
+// relabel moves the vertices of component from reachable over forest edges
+// from start into component to
+func (d *DynamicConnectivity) relabel(start, from, to int) {
+	d.component[start] = to
+	queue := []int{start}
+	for head := 0; head < len(queue); head++ {
+		v := queue[head]
+		for id, tree := range d.incident[v] {
+			if u := d.other(id, v); tree && d.component[u] == from {
+				d.component[u] = to
+				queue = append(queue, u)
+			}
+		}
+	}
+}
+
+// cut repairs the spanning forest after the forest edge between x and y was
+// removed, either with a replacement edge or by splitting the component
+func (d *DynamicConnectivity) cut(x, y int) {
+	side := d.smallerHalf(x, y)
+	inSide := make(map[int]bool, len(side))
+	for _, v := range side {
+		inSide[v] = true
+	}
+	for _, v := range side {
+		for id, tree := range d.incident[v] {
+			if u := d.other(id, v); !tree && !inSide[u] {
+				d.incident[v][id] = true
+				d.incident[u][id] = true
+				return
+			}
+		}
+	}
+	c := d.component[x]
+	d.lastComponent++
+	for _, v := range side {
+		d.component[v] = d.lastComponent
+	}
+	d.size[d.lastComponent] = len(side)
+	d.size[c] -= len(side)
+}
+
+// smallerHalf searches the forest from x and from y in lockstep and returns
+// the vertices of whichever tree is exhausted first
+func (d *DynamicConnectivity) smallerHalf(x, y int) []int {
+	halves := [2][]int{{x}, {y}}
+	heads := [2]int{}
+	seen := map[int]bool{x: true, y: true}
+	for {
+		for i := range halves {
+			if heads[i] == len(halves[i]) {
+				return halves[i]
+			}
+			v := halves[i][heads[i]]
+			heads[i]++
+			for id, tree := range d.incident[v] {
+				if u := d.other(id, v); tree && !seen[u] {
+					seen[u] = true
+					halves[i] = append(halves[i], u)
+				}
+			}
+		}
+	}
+}
+
+// other returns the endpoint of edge id that is not v
+func (d *DynamicConnectivity) other(id, v int) int {
+	ends := d.g.edgeEnds[id]
+	if ends[0] == v {
+		return ends[1]
+	}
+	return ends[0]
+}
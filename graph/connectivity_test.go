@@ -0,0 +1,125 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestDynamicConnectivity(t *testing.T) {
+	g := initGraph(false)
+	d := g.DynamicConnectivity()
+	if d.NumComponents() != 2 || !d.Connected(1, 5) || d.Connected(1, 7) {
+		t.Fatalf("Incorrect initial components: %v", d.Components())
+	}
+
+	// 2-3 lies on the cycle 2-3-4-5, so removing it keeps the component
+	if err := d.RemoveEdge(g.EdgeIDs(2, 3)[0]); err != nil {
+		t.Fatal(err)
+	}
+	if d.NumComponents() != 2 || !d.Connected(2, 3) {
+		t.Errorf("Removing a cycle edge split a component: %v", d.Components())
+	}
+	// Now 3 hangs off 4 alone
+	d.RemoveEdge(g.EdgeIDs(3, 4)[0])
+	if d.NumComponents() != 3 || d.Connected(3, 4) || d.Component(3) == 0 {
+		t.Errorf("Removing a bridge should isolate 3: %v", d.Components())
+	}
+
+	id, err := d.AddEdge(3, 8, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.NumComponents() != 2 || !d.Connected(3, 10) || d.Connected(3, 1) {
+		t.Errorf("Incorrect components after adding 3-8: %v", d.Components())
+	}
+	d.RemoveEdge(id)
+	if d.Connected(3, 8) {
+		t.Error("Removing 3-8 should disconnect 3 again")
+	}
+
+	// New vertices start in a component of their own
+	d.AddEdge(12, 12, 0)
+	if d.NumComponents() != 5 || d.Connected(11, 12) || !d.Connected(11, 11) {
+		t.Errorf("Incorrect components of new vertices: %v", d.Components())
+	}
+	if d.Connected(0, 0) || d.Component(13) != 0 {
+		t.Error("Vertices outside the graph should not be connected")
+	}
+	if err := d.RemoveEdge(id); err == nil {
+		t.Error("Removing a missing edge should fail")
+	}
+}
+
+func TestDynamicConnectivityParallelEdges(t *testing.T) {
+	g := NewGraph(true)
+	d := g.DynamicConnectivity()
+	a, _ := d.AddEdge(1, 2, 0)
+	b, _ := d.AddEdge(2, 1, 0)
+	d.AddEdge(2, 3, 0)
+	if !d.Connected(3, 1) {
+		t.Error("Directed edges should connect both ways")
+	}
+	d.RemoveEdge(a)
+	if !d.Connected(1, 2) {
+		t.Error("The parallel edge should keep 1 and 2 connected")
+	}
+	d.RemoveEdge(b)
+	if d.Connected(1, 2) || !d.Connected(2, 3) {
+		t.Errorf("Incorrect components: %v", d.Components())
+	}
+
+	s := NewSimpleGraph(false)
+	d = s.DynamicConnectivity()
+	d.AddEdge(1, 2, 0)
+	if _, err := d.AddEdge(2, 1, 0); err == nil {
+		t.Error("Simple graphs should reject parallel edges")
+	}
+}
+
+func TestRandomDynamicConnectivity(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	g := randomWeightedGraph(40, 0.04, 5)
+	d := g.DynamicConnectivity()
+	for step := 0; step < 2000; step++ {
+		ids := []int{}
+		for _, e := range g.EdgeList() {
+			ids = append(ids, e.ID)
+		}
+		if len(ids) > 0 && r.Intn(2) == 0 {
+			d.RemoveEdge(ids[r.Intn(len(ids))])
+		} else {
+			d.AddEdge(r.Intn(40)+1, r.Intn(40)+1, 0)
+		}
+
+		want := make(map[int]int)
+		components := g.ConnectedComponents()
+		for c, vertices := range components {
+			for _, v := range vertices {
+				want[v] = c
+			}
+		}
+		if d.NumComponents() != len(components) {
+			t.Fatalf("Step %d: %d components, want %d", step, d.NumComponents(), len(components))
+		}
+		for x := 1; x <= 40; x++ {
+			for y := x + 1; y <= 40; y++ {
+				if d.Connected(x, y) != (want[x] == want[y]) {
+					t.Fatalf("Step %d: Connected(%d, %d) = %v", step, x, y, d.Connected(x, y))
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkDynamicConnectivity(b *testing.B) {
+	g := GridGraph(100, 100)
+	d := g.DynamicConnectivity()
+	edges := g.EdgeList()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		e := edges[i%len(edges)]
+		d.RemoveEdge(e.ID)
+		id, _ := d.AddEdge(e.X, e.Y, e.Weight)
+		edges[i%len(edges)].ID = id
+	}
+}
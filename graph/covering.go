@@ -0,0 +1,254 @@
+package graph
+
+import (
+	"context"
+	"sort"
+)
+
+// Covering problems ignore edge direction. A vertex with a self-loop is
+// adjacent to itself, so it belongs to every vertex cover and to no
+// independent set.
+
+// VertexCover returns a set of vertices touching every edge that is at most
+// twice as large as the smallest one. It takes both endpoints of every edge of
+// a maximal matching, built greedily in EdgeList order.
+func (g *Graph) VertexCover() []int {
+	inCover := make([]bool, g.nVertices+1)
+	cover := []int{}
+	for _, e := range g.EdgeList() {
+		if inCover[e.X] || inCover[e.Y] {
+			continue
+		}
+		inCover[e.X] = true
+		cover = append(cover, e.X)
+		if e.Y != e.X {
+			inCover[e.Y] = true
+			cover = append(cover, e.Y)
+		}
+	}
+	sort.Ints(cover)
+	return cover
+}
+
+// DominatingSet returns a set of vertices such that every vertex is in it or
+// adjacent to it. It repeatedly takes the vertex that dominates the most
+// vertices not yet dominated, which is within a factor of ln(n)+1 of the
+// smallest dominating set.
+func (g *Graph) DominatingSet() []int {
+	nbrs := g.simpleNeighbors()
+	n := g.nVertices
+	dominated := make([]bool, n+1)
+	gain := make([]int, n+1) // Vertices not yet dominated in the closed neighbourhood
+	for v := 1; v <= n; v++ {
+		gain[v] = len(nbrs[v]) + 1
+	}
+	set := []int{}
+	for remaining := n; remaining > 0; {
+		best := 0
+		for v := 1; v <= n; v++ {
+			if gain[v] > gain[best] {
+				best = v
+			}
+		}
+		set = append(set, best)
+		for _, u := range append([]int{best}, nbrs[best]...) {
+			if dominated[u] {
+				continue
+			}
+			dominated[u] = true
+			remaining--
+			gain[u]--
+			for _, w := range nbrs[u] {
+				gain[w]--
+			}
+		}
+	}
+	sort.Ints(set)
+	return set
+}
+
+// MaximumIndependentSet finds a largest set of pairwise non-adjacent vertices
+// by branch and bound. Vertices with at most one neighbour left are taken
+// without branching, and branches are cut when a greedy clique cover of the
+// remaining vertices shows they cannot beat the best set found. The search is
+// exponential so it is meant for small graphs; if ctx is done before the
+// search completes, the best set found so far is returned along with the
+// context's error.
+func (g *Graph) MaximumIndependentSet(ctx context.Context) ([]int, error) {
+	s := &independentSetSearch{ctx: ctx, nbrs: g.simpleNeighbors()}
+	s.mark = make([]int, g.nVertices+1)
+	loops := make([]bool, g.nVertices+1)
+	for x, edgeNode := range g.Edges {
+		for ; edgeNode != nil; edgeNode = edgeNode.Next {
+			if x == edgeNode.Y {
+				loops[x] = true
+			}
+		}
+	}
+	candidates := []int{}
+	for v := 1; v <= g.nVertices; v++ {
+		if !loops[v] {
+			candidates = append(candidates, v)
+		}
+	}
+	s.best = s.greedy(candidates)
+	s.search(candidates)
+	sort.Ints(s.best)
+	return s.best, s.err
+}
+
+// MinimumVertexCover finds a smallest vertex cover as the complement of a
+// maximum independent set. If ctx is done first, the cover found so far is
+// returned along with the context's error.
+func (g *Graph) MinimumVertexCover(ctx context.Context) ([]int, error) {
+	independent, err := g.MaximumIndependentSet(ctx)
+	inSet := make([]bool, g.nVertices+1)
+	for _, v := range independent {
+		inSet[v] = true
+	}
+	cover := []int{}
+	for v := 1; v <= g.nVertices; v++ {
+		if !inSet[v] {
+			cover = append(cover, v)
+		}
+	}
+	return cover, err
+}
+
+// independentSetSearch holds the state of the branch and bound search
+type independentSetSearch struct {
+	ctx     context.Context
+	nbrs    [][]int
+	mark    []int // Equal to stamp for the vertices of the current candidate set
+	stamp   int
+	current []int
+	best    []int
+	err     error
+}
+
+// markCandidates gives the candidates a fresh stamp so membership can be
+// tested in constant time
+func (s *independentSetSearch) markCandidates(candidates []int) {
+	s.stamp++
+	for _, v := range candidates {
+		s.mark[v] = s.stamp
+	}
+}
+
+// degree counts the neighbours of v among the marked candidates
+func (s *independentSetSearch) degree(v int) int {
+	d := 0
+	for _, u := range s.nbrs[v] {
+		if s.mark[u] == s.stamp {
+			d++
+		}
+	}
+	return d
+}
+
+// without returns the candidates other than v and, if closed is set, its
+// neighbours
+func (s *independentSetSearch) without(candidates []int, v int, closed bool) []int {
+	excluded := map[int]bool{v: true}
+	if closed {
+		for _, u := range s.nbrs[v] {
+			excluded[u] = true
+		}
+	}
+	rest := make([]int, 0, len(candidates))
+	for _, u := range candidates {
+		if !excluded[u] {
+			rest = append(rest, u)
+		}
+	}
+	return rest
+}
+
+// greedy builds an independent set by repeatedly taking a vertex of minimum
+// degree among the candidates
+func (s *independentSetSearch) greedy(candidates []int) []int {
+	set := []int{}
+	for len(candidates) > 0 {
+		s.markCandidates(candidates)
+		v := candidates[0]
+		for _, u := range candidates {
+			if s.degree(u) < s.degree(v) {
+				v = u
+			}
+		}
+		set = append(set, v)
+		candidates = s.without(candidates, v, true)
+	}
+	return set
+}
+
+// cliqueCover partitions the candidates greedily into cliques. An independent
+// set holds at most one vertex of each, so their number bounds its size.
+func (s *independentSetSearch) cliqueCover(candidates []int) int {
+	cliques := [][]int{}
+	for _, v := range candidates {
+		placed := false
+		for i, clique := range cliques {
+			if s.adjacentToAll(v, clique) {
+				cliques[i] = append(clique, v)
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			cliques = append(cliques, []int{v})
+		}
+	}
+	return len(cliques)
+}
+
+func (s *independentSetSearch) adjacentToAll(v int, vertices []int) bool {
+	for _, u := range vertices {
+		i := sort.SearchInts(s.nbrs[v], u)
+		if i == len(s.nbrs[v]) || s.nbrs[v][i] != u {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *independentSetSearch) search(candidates []int) {
+	if s.err != nil {
+		return
+	}
+	if err := s.ctx.Err(); err != nil {
+		s.err = err
+		return
+	}
+	if len(s.current)+len(candidates) <= len(s.best) {
+		return
+	}
+	if len(candidates) == 0 {
+		s.best = append([]int{}, s.current...)
+		return
+	}
+
+	s.markCandidates(candidates)
+	branch, maxDegree := 0, -1
+	for _, v := range candidates {
+		d := s.degree(v)
+		if d <= 1 {
+			// Some maximum independent set contains a vertex of degree 0 or 1
+			s.current = append(s.current, v)
+			s.search(s.without(candidates, v, true))
+			s.current = s.current[:len(s.current)-1]
+			return
+		}
+		if d > maxDegree {
+			branch, maxDegree = v, d
+		}
+	}
+	if len(s.current)+s.cliqueCover(candidates) <= len(s.best) {
+		return
+	}
+
+	s.current = append(s.current, branch)
+	s.search(s.without(candidates, branch, true))
+	s.current = s.current[:len(s.current)-1]
+	s.search(s.without(candidates, branch, false))
+}
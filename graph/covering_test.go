@@ -0,0 +1,155 @@
+package graph
+
+import (
+	"context"
+	"math/bits"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func isVertexCover(g *Graph, cover []int) bool {
+	in := make(map[int]bool)
+	for _, v := range cover {
+		in[v] = true
+	}
+	for _, e := range g.EdgeList() {
+		if !in[e.X] && !in[e.Y] {
+			return false
+		}
+	}
+	return true
+}
+
+func isIndependentSet(g *Graph, set []int) bool {
+	in := make(map[int]bool)
+	for _, v := range set {
+		in[v] = true
+	}
+	for _, e := range g.EdgeList() {
+		if in[e.X] && in[e.Y] {
+			return false
+		}
+	}
+	return true
+}
+
+func isDominatingSet(g *Graph, set []int) bool {
+	dominated := make(map[int]bool)
+	for _, v := range set {
+		dominated[v] = true
+	}
+	in := make(map[int]bool)
+	for v := range dominated {
+		in[v] = true
+	}
+	for _, e := range g.EdgeList() {
+		if in[e.X] {
+			dominated[e.Y] = true
+		}
+		if in[e.Y] {
+			dominated[e.X] = true
+		}
+	}
+	return len(dominated) == g.NumVertices()
+}
+
+// bruteForceIndependenceNumber tries every subset of the vertices
+func bruteForceIndependenceNumber(g *Graph) int {
+	best := 0
+	for mask := 0; mask < 1<<uint(g.NumVertices()); mask++ {
+		set := []int{}
+		for v := 1; v <= g.NumVertices(); v++ {
+			if mask&(1<<uint(v-1)) != 0 {
+				set = append(set, v)
+			}
+		}
+		if len(set) > best && isIndependentSet(g, set) {
+			best = bits.OnesCount(uint(mask))
+		}
+	}
+	return best
+}
+
+func TestCovering(t *testing.T) {
+	g := initGraph(false)
+	cover := g.VertexCover()
+	if !isVertexCover(g, cover) || len(cover) > 2*5 {
+		t.Errorf("Incorrect vertex cover %v", cover)
+	}
+
+	// 1-6, 2-3-4-5 and 7-8-9-10 need one, two and two vertices
+	min, err := g.MinimumVertexCover(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isVertexCover(g, min) || len(min) != 5 {
+		t.Errorf("Incorrect minimum vertex cover %v", min)
+	}
+	independent, err := g.MaximumIndependentSet(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isIndependentSet(g, independent) || len(independent) != 5 {
+		t.Errorf("Incorrect maximum independent set %v", independent)
+	}
+
+	dominating := g.DominatingSet()
+	// The smallest has four vertices, greedy takes the degree three vertex 2 first
+	if !isDominatingSet(g, dominating) || len(dominating) > 5 {
+		t.Errorf("Incorrect dominating set %v", dominating)
+	}
+	if set := StarGraph(6).DominatingSet(); len(set) != 1 || set[0] != 1 {
+		t.Errorf("The centre should dominate a star: %v", set)
+	}
+
+	// Self-loops force a vertex into every cover
+	g = NewGraph(true)
+	g.InsertEdge(1, 1, true)
+	g.InsertEdge(1, 2, true)
+	g.InsertVertex(3)
+	if cover := g.VertexCover(); !isVertexCover(g, cover) || cover[0] != 1 {
+		t.Errorf("Incorrect vertex cover with a self-loop: %v", cover)
+	}
+	if set, _ := g.MaximumIndependentSet(context.Background()); len(set) != 2 || set[0] != 2 {
+		t.Errorf("Incorrect independent set with a self-loop: %v", set)
+	}
+}
+
+func TestRandomCovering(t *testing.T) {
+	r := rand.New(rand.NewSource(11))
+	for i := 0; i < 60; i++ {
+		n := r.Intn(14) + 1
+		g := ErdosRenyi(n, r.Float64(), r)
+		alpha := bruteForceIndependenceNumber(g)
+
+		independent, err := g.MaximumIndependentSet(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !isIndependentSet(g, independent) || len(independent) != alpha {
+			t.Fatalf("Graph %d: independent set %v, want size %d", i, independent, alpha)
+		}
+		cover := g.VertexCover()
+		if !isVertexCover(g, cover) || len(cover) > 2*(n-alpha) {
+			t.Fatalf("Graph %d: vertex cover %v is more than twice the minimum %d", i, cover, n-alpha)
+		}
+		if set := g.DominatingSet(); !isDominatingSet(g, set) {
+			t.Fatalf("Graph %d: %v is not dominating", i, set)
+		}
+	}
+}
+
+func TestMaximumIndependentSetTimeout(t *testing.T) {
+	g := ErdosRenyi(200, 0.1, rand.NewSource(3))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+	set, err := g.MaximumIndependentSet(ctx)
+	if err == nil {
+		t.Error("Expected the search to be interrupted")
+	}
+	if len(set) == 0 || !isIndependentSet(g, set) {
+		t.Error("Interrupted search did not return an independent set")
+	}
+}
@@ -0,0 +1,175 @@
+package graph
+
+import "errors"
+
+// CSR is an immutable compressed sparse row copy of a Graph. The neighbours of
+// vertex v are Targets[Offsets[v]:Offsets[v+1]] with matching Weights, kept in
+// the same order as the Graph's adjacency list so traversals agree. Flat
+// arrays make it far cheaper than the pointer-linked EdgeNode lists for large
+// graphs.
+type CSR struct {
+	Offsets   []int
+	Targets   []int
+	Weights   []int
+	Directed  bool
+	nVertices int
+	nEdges    int
+}
+
+// Freeze builds a CSR snapshot of the graph. Later changes to the graph are
+// not reflected in the snapshot.
+func (g *Graph) Freeze() *CSR {
+	c := &CSR{
+		Offsets:   make([]int, g.nVertices+2),
+		Directed:  g.Directed,
+		nVertices: g.nVertices,
+		nEdges:    g.nEdges,
+	}
+	total := 0
+	for v := 1; v <= g.nVertices; v++ {
+		c.Offsets[v] = total
+		for edgeNode := g.Edges[v]; edgeNode != nil; edgeNode = edgeNode.Next {
+			total++
+		}
+	}
+	c.Offsets[g.nVertices+1] = total
+	c.Targets = make([]int, total)
+	c.Weights = make([]int, total)
+	for v := 1; v <= g.nVertices; v++ {
+		i := c.Offsets[v]
+		for edgeNode := g.Edges[v]; edgeNode != nil; edgeNode = edgeNode.Next {
+			c.Targets[i] = edgeNode.Y
+			c.Weights[i] = edgeNode.Weight
+			i++
+		}
+	}
+	return c
+}
+
+// NumVertices returns the number of vertices in the snapshot
+func (c *CSR) NumVertices() int {
+	return c.nVertices
+}
+
+// NumEdges returns the number of edges in the snapshot
+func (c *CSR) NumEdges() int {
+	return c.nEdges
+}
+
+// hasVertex reports whether v is one of the vertices 1..n of the snapshot
+func (c *CSR) hasVertex(v int) bool {
+	return v >= 1 && v <= c.nVertices
+}
+
+// Neighbors returns the adjacent vertices of v, or nil if v is not a vertex.
+// The slice must not be modified.
+func (c *CSR) Neighbors(v int) []int {
+	if !c.hasVertex(v) {
+		return nil
+	}
+	return c.Targets[c.Offsets[v]:c.Offsets[v+1]]
+}
+
+// bfs mirrors Graph.bfs, reporting vertices and edges to the visit callbacks
+// and returning the BFS tree parents (-1 for roots and unreached vertices).
+// start must be a vertex of the snapshot.
+func (c *CSR) bfs(start int, visitVertex func(int), visitEdge func(int, int)) []int {
+	state := make([]VerticeState, c.nVertices+1)
+	parent := make([]int, c.nVertices+1)
+	for i := range parent {
+		state[i] = UNDISCOVERED
+		parent[i] = -1
+	}
+	queue := make([]int, 0, c.nVertices)
+	queue = append(queue, start)
+	state[start] = DISCOVERED
+	for head := 0; head < len(queue); head++ {
+		v := queue[head]
+		visitVertex(v)
+		state[v] = PROCESSED
+		for _, y := range c.Neighbors(v) {
+			if state[y] != PROCESSED || c.Directed {
+				visitEdge(v, y)
+			}
+			if state[y] == UNDISCOVERED {
+				queue = append(queue, y)
+				state[y] = DISCOVERED
+				parent[y] = v
+			}
+		}
+	}
+	return parent
+}
+
+// BreadthFirstSearch returns the same discovery sequence as
+// Graph.BreadthFirstSearch: single values are vertices, pairs are edges. It
+// returns nil if start is not a vertex.
+func (c *CSR) BreadthFirstSearch(start int) [][]int {
+	if !c.hasVertex(start) {
+		return nil
+	}
+	visits := [][]int{}
+	c.bfs(start, func(v int) {
+		visits = append(visits, []int{v})
+	}, func(x, y int) {
+		visits = append(visits, []int{x, y})
+	})
+	return visits
+}
+
+// DepthFirstSearch returns the same discovery sequence as
+// Graph.DepthFirstSearch. It uses an explicit stack so deep graphs cannot
+// overflow the goroutine stack. It returns nil if start is not a vertex.
+func (c *CSR) DepthFirstSearch(start int) [][]int {
+	if !c.hasVertex(start) {
+		return nil
+	}
+	visits := [][]int{}
+	state := make([]VerticeState, c.nVertices+1)
+	next := make([]int, c.nVertices+1) // next edge to explore from each vertex
+	for i := range state {
+		state[i] = UNDISCOVERED
+	}
+	stack := []int{start}
+	state[start] = DISCOVERED
+	next[start] = c.Offsets[start]
+	visits = append(visits, []int{start})
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		if next[v] == c.Offsets[v+1] {
+			state[v] = PROCESSED
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		y := c.Targets[next[v]]
+		next[v]++
+		if state[y] == UNDISCOVERED {
+			visits = append(visits, []int{v, y})
+			state[y] = DISCOVERED
+			next[y] = c.Offsets[y]
+			visits = append(visits, []int{y})
+			stack = append(stack, y)
+		} else if state[y] != PROCESSED || c.Directed {
+			visits = append(visits, []int{v, y})
+		}
+	}
+	return visits
+}
+
+// FindPath finds the shortest path between start and end in an unweighted graph
+func (c *CSR) FindPath(start, end int) ([]int, error) {
+	if !c.hasVertex(start) || !c.hasVertex(end) {
+		return nil, errors.New("Vertex is not in the graph")
+	}
+	parent := c.bfs(start, func(int) {}, func(int, int) {})
+	if parent[end] == -1 && start != end {
+		return nil, errors.New("No Path exists")
+	}
+	path := []int{}
+	for v := end; v != start; v = parent[v] {
+		path = append(path, v)
+	}
+	path = append(path, start)
+	reverse(path, 0, len(path)-1)
+	return path, nil
+}
@@ -0,0 +1,107 @@
+package graph
+
+import (
+	"flag"
+	"math/rand"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestCSRTraversals(t *testing.T) {
+	for _, directed := range []bool{true, false} {
+		g := initGraph(directed)
+		c := g.Freeze()
+		if c.NumVertices() != g.NumVertices() || c.NumEdges() != g.NumEdges() {
+			t.Error("Snapshot has different vertex or edge counts")
+		}
+		g.InitSearch()
+		if !reflect.DeepEqual(c.BreadthFirstSearch(1), g.BreadthFirstSearch(1)) {
+			t.Error("CSR and Graph disagree on breadth first search")
+		}
+		g.InitSearch()
+		if !reflect.DeepEqual(c.DepthFirstSearch(1), g.DepthFirstSearch(1)) {
+			t.Error("CSR and Graph disagree on depth first search")
+		}
+	}
+}
+
+func TestCSRFindPath(t *testing.T) {
+	c := initGraph(true).Freeze()
+	path, err := c.FindPath(1, 5)
+	if err != nil || !reflect.DeepEqual(path, []int{1, 2, 3, 4, 5}) {
+		t.Error("Incorrect shortest path found")
+	}
+	if _, err := c.FindPath(6, 5); err == nil {
+		t.Error("Found path when none exists")
+	}
+}
+
+func TestCSRVertexRange(t *testing.T) {
+	c := initGraph(true).Freeze()
+	for _, v := range []int{0, -1, 11} {
+		if c.Neighbors(v) != nil || c.BreadthFirstSearch(v) != nil || c.DepthFirstSearch(v) != nil {
+			t.Errorf("Vertex %v is not in the graph and should have no traversal", v)
+		}
+		if _, err := c.FindPath(1, v); err == nil {
+			t.Errorf("Expected an error for a path to vertex %v", v)
+		}
+		if _, err := c.FindPath(v, 1); err == nil {
+			t.Errorf("Expected an error for a path from vertex %v", v)
+		}
+	}
+}
+
+// benchEdges sets the size of the CSR benchmarks. The default graph is far
+// larger than the CPU caches, which is where the flat arrays pay off; raise it
+// to time production sized graphs, e.g.
+//
+//	go test -run XXX -bench CSR -bench.edges 20000000 ./graph
+var benchEdges = flag.Int("bench.edges", 1000000, "number of edges in the graph of the CSR benchmarks")
+
+var largeGraph struct {
+	once sync.Once
+	g    *Graph
+}
+
+// largeBenchmarkGraph builds a random graph with *benchEdges edges and an
+// average degree of 16 once, and shares it between benchmarks
+func largeBenchmarkGraph() *Graph {
+	largeGraph.once.Do(func() {
+		largeGraph.g, _ = ErdosRenyiM(*benchEdges/8, *benchEdges, rand.NewSource(1))
+	})
+	return largeGraph.g
+}
+
+func BenchmarkGraphBFS(b *testing.B) {
+	g := largeBenchmarkGraph()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.FindPath(1, g.NumVertices())
+	}
+}
+
+func BenchmarkCSRBFS(b *testing.B) {
+	c := largeBenchmarkGraph().Freeze()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.FindPath(1, c.NumVertices())
+	}
+}
+
+func BenchmarkGraphDFS(b *testing.B) {
+	g := largeBenchmarkGraph()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.InitSearch()
+		g.DepthFirstSearch(1)
+	}
+}
+
+func BenchmarkCSRDFS(b *testing.B) {
+	c := largeBenchmarkGraph().Freeze()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.DepthFirstSearch(1)
+	}
+}
@@ -0,0 +1,243 @@
+package graph
+
+import (
+	"errors"
+	"sort"
+)
+
+// A Patch describes the changes that turn one graph into another. It encodes
+// to JSON with encoding/json, so snapshots can be compared and patched across
+// processes.
+type Patch struct {
+	AddedVertices    []int              `json:"addedVertices,omitempty"`
+	RemovedVertices  []int              `json:"removedVertices,omitempty"`
+	AddedEdges       []Edge             `json:"addedEdges,omitempty"`
+	RemovedEdges     []Edge             `json:"removedEdges,omitempty"`
+	ChangedEdges     []EdgeChange       `json:"changedEdges,omitempty"`
+	VertexAttributes map[int]Attributes `json:"vertexAttributes,omitempty"` // New attributes of vertices whose attributes changed
+}
+
+// An EdgeChange is an edge whose weight or attributes changed
+type EdgeChange struct {
+	From Edge `json:"from"`
+	To   Edge `json:"to"`
+}
+
+// Empty reports whether the patch changes nothing
+func (p *Patch) Empty() bool {
+	return len(p.AddedVertices) == 0 && len(p.RemovedVertices) == 0 && len(p.AddedEdges) == 0 &&
+		len(p.RemovedEdges) == 0 && len(p.ChangedEdges) == 0 && len(p.VertexAttributes) == 0
+}
+
+// Diff compares two graphs and returns the patch that turns a into b.
+// Vertices are numbered 1..n, so vertices are added or removed at the end.
+// Edges of a and b are matched by id when the ids agree on the endpoints, as
+// they do when b was derived from a, and otherwise by endpoints, pairing
+// parallel edges in EdgeList order. Matched edges whose weight or attributes
+// differ are reported as changed.
+func Diff(a, b *Graph) (*Patch, error) {
+	if a.Directed != b.Directed {
+		return nil, errors.New("Graphs must both be directed or both undirected")
+	}
+	p := &Patch{}
+	for v := a.nVertices + 1; v <= b.nVertices; v++ {
+		p.AddedVertices = append(p.AddedVertices, v)
+	}
+	for v := b.nVertices + 1; v <= a.nVertices; v++ {
+		p.RemovedVertices = append(p.RemovedVertices, v)
+	}
+	for v := 1; v <= b.nVertices; v++ {
+		if (v > a.nVertices && len(b.vertexAttributes[v]) > 0) ||
+			(v <= a.nVertices && !sameAttributes(a.vertexAttributes[v], b.vertexAttributes[v])) {
+			if p.VertexAttributes == nil {
+				p.VertexAttributes = make(map[int]Attributes)
+			}
+			p.VertexAttributes[v] = b.vertexAttributes[v].clone()
+		}
+	}
+
+	aEdges, bEdges := a.EdgeList(), b.EdgeList()
+	match := make([]int, len(aEdges)) // Index of the matching edge of b, -1 if removed
+	matched := make([]bool, len(bEdges))
+	byID := make(map[int]int)
+	for j, e := range bEdges {
+		byID[e.ID] = j
+	}
+	for i, e := range aEdges {
+		match[i] = -1
+		if j, ok := byID[e.ID]; ok && bEdges[j].X == e.X && bEdges[j].Y == e.Y {
+			match[i] = j
+			matched[j] = true
+		}
+	}
+	byEnds := make(map[[2]int][]int) // Unmatched edges of b by endpoints
+	for j, e := range bEdges {
+		if !matched[j] {
+			byEnds[[2]int{e.X, e.Y}] = append(byEnds[[2]int{e.X, e.Y}], j)
+		}
+	}
+	for i, e := range aEdges {
+		if candidates := byEnds[[2]int{e.X, e.Y}]; match[i] == -1 && len(candidates) > 0 {
+			match[i] = candidates[0]
+			matched[candidates[0]] = true
+			byEnds[[2]int{e.X, e.Y}] = candidates[1:]
+		}
+	}
+
+	for i, e := range aEdges {
+		if match[i] == -1 {
+			p.RemovedEdges = append(p.RemovedEdges, e)
+			continue
+		}
+		to := bEdges[match[i]]
+		if e.Weight != to.Weight || !sameAttributes(e.Attributes, to.Attributes) {
+			p.ChangedEdges = append(p.ChangedEdges, EdgeChange{From: e, To: to})
+		}
+	}
+	for j, e := range bEdges {
+		if !matched[j] {
+			p.AddedEdges = append(p.AddedEdges, e)
+		}
+	}
+	return p, nil
+}
+
+// sameAttributes reports whether two attribute sets hold the same pairs,
+// treating nil as empty
+func sameAttributes(a, b Attributes) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if w, ok := b[k]; !ok || w != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply changes the graph as described by the patch. Removed and changed
+// edges are looked up by id and must still have the recorded endpoints.
+// Added edges keep their ids unless the id is already taken. The patch is
+// checked before anything changes, so a patch that does not fit the graph
+// returns an error and leaves the graph as it was. That includes a patch that
+// would give a simple graph a parallel edge or a self-loop.
+func (g *Graph) Apply(patch *Patch) error {
+	n := g.nVertices + len(patch.AddedVertices) - len(patch.RemovedVertices)
+	if err := g.checkPatch(patch, n); err != nil {
+		return err
+	}
+
+	for _, e := range patch.RemovedEdges {
+		g.RemoveEdge(e.ID)
+	}
+	for _, c := range patch.ChangedEdges {
+		g.SetEdgeWeight(c.From.ID, c.To.Weight)
+		attributes := c.To.Attributes.clone()
+		for _, edgeNode := range g.edgeNodes(c.From.ID) {
+			edgeNode.Attributes = attributes
+		}
+	}
+	for v := n + 1; v <= g.nVertices; v++ {
+		delete(g.Edges, v)
+		delete(g.Degree, v)
+		delete(g.vertexAttributes, v)
+	}
+	g.nVertices = n
+	for _, e := range patch.AddedEdges {
+		g.insertEdge(e.ID, e.X, e.Y, e.Weight, e.Attributes.clone(), g.Directed)
+	}
+	if g.vertexAttributes == nil {
+		g.vertexAttributes = make(map[int]Attributes)
+	}
+	for v, a := range patch.VertexAttributes {
+		if len(a) == 0 {
+			delete(g.vertexAttributes, v)
+		} else {
+			g.vertexAttributes[v] = a.clone()
+		}
+	}
+	return nil
+}
+
+// checkPatch verifies that the patch fits the graph and leaves n vertices
+func (g *Graph) checkPatch(patch *Patch, n int) error {
+	if len(patch.AddedVertices) > 0 && len(patch.RemovedVertices) > 0 {
+		return errors.New("Patch both adds and removes vertices")
+	}
+	vertices, first := patch.AddedVertices, g.nVertices+1
+	if len(patch.RemovedVertices) > 0 {
+		vertices, first = patch.RemovedVertices, n+1
+	}
+	vertices = append([]int{}, vertices...)
+	sort.Ints(vertices)
+	for i, v := range vertices {
+		if v != first+i {
+			return errors.New("Vertices can only be added or removed at the end")
+		}
+	}
+
+	removed := make(map[int]bool)
+	for _, e := range patch.RemovedEdges {
+		if removed[e.ID] || !g.hasEdge(e) {
+			return errors.New("Patch removes an edge that is not in the graph")
+		}
+		removed[e.ID] = true
+	}
+	changed := make(map[int]bool)
+	for _, c := range patch.ChangedEdges {
+		if removed[c.From.ID] || changed[c.From.ID] || !g.hasEdge(c.From) {
+			return errors.New("Patch changes an edge that is not in the graph")
+		}
+		changed[c.From.ID] = true
+	}
+	for id, ends := range g.edgeEnds {
+		if !removed[id] && (ends[0] > n || ends[1] > n) {
+			return errors.New("Patch removes a vertex that still has edges")
+		}
+	}
+	for _, e := range patch.AddedEdges {
+		if e.X < 1 || e.X > n || e.Y < 1 || e.Y > n {
+			return errors.New("Edge endpoint is not a vertex of the graph")
+		}
+	}
+	if g.simple {
+		pair := func(x, y int) [2]int {
+			if !g.Directed && x > y {
+				return [2]int{y, x}
+			}
+			return [2]int{x, y}
+		}
+		pairs := make(map[[2]int]bool)
+		for id, ends := range g.edgeEnds {
+			if !removed[id] {
+				pairs[pair(ends[0], ends[1])] = true
+			}
+		}
+		for _, e := range patch.AddedEdges {
+			if e.X == e.Y {
+				return errors.New("Simple graphs cannot have self-loops")
+			}
+			if pairs[pair(e.X, e.Y)] {
+				return errors.New("Edge already exists")
+			}
+			pairs[pair(e.X, e.Y)] = true
+		}
+	}
+	for v := range patch.VertexAttributes {
+		if v < 1 || v > n {
+			return errors.New("Vertex attributes refer to an unknown vertex")
+		}
+	}
+	return nil
+}
+
+// hasEdge reports whether the graph holds edge e under its id, with the same
+// endpoints in either order for undirected graphs
+func (g *Graph) hasEdge(e Edge) bool {
+	ends, ok := g.edgeEnds[e.ID]
+	if !ok {
+		return false
+	}
+	return ends == [2]int{e.X, e.Y} || !g.Directed && ends == [2]int{e.Y, e.X}
+}
@@ -0,0 +1,179 @@
+package graph
+
+import (
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	a := initGraph(false)
+	b := a.Transpose() // A copy keeping the edge ids
+	b.RemoveEdge(b.EdgeIDs(2, 3)[0])
+	b.SetEdgeWeight(b.EdgeIDs(7, 8)[0], 4)
+	b.SetEdgeAttribute(1, 6, "kind", "road")
+	b.AddEdge(10, 12, 2)
+	b.SetVertexAttribute(3, "name", "c")
+
+	p, err := Diff(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(p.AddedVertices, []int{11, 12}) || len(p.RemovedVertices) != 0 {
+		t.Errorf("Incorrect vertex changes: %v %v", p.AddedVertices, p.RemovedVertices)
+	}
+	if len(p.RemovedEdges) != 1 || p.RemovedEdges[0].X != 2 || p.RemovedEdges[0].Y != 3 {
+		t.Errorf("Incorrect removed edges: %v", p.RemovedEdges)
+	}
+	if len(p.AddedEdges) != 1 || p.AddedEdges[0].X != 10 || p.AddedEdges[0].Weight != 2 {
+		t.Errorf("Incorrect added edges: %v", p.AddedEdges)
+	}
+	if len(p.ChangedEdges) != 2 {
+		t.Fatalf("Incorrect changed edges: %v", p.ChangedEdges)
+	}
+	for _, c := range p.ChangedEdges {
+		if c.From.X == 7 && (c.From.Weight != 0 || c.To.Weight != 4) {
+			t.Errorf("Incorrect weight change: %v", c)
+		}
+		if c.From.X == 1 && c.To.Attributes["kind"] != "road" {
+			t.Errorf("Incorrect attribute change: %v", c)
+		}
+	}
+	if !reflect.DeepEqual(p.VertexAttributes, map[int]Attributes{3: {"name": "c"}}) {
+		t.Errorf("Incorrect vertex attribute changes: %v", p.VertexAttributes)
+	}
+
+	// Patches survive a JSON round trip
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Patch
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Apply(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	if p, _ := Diff(a, b); !p.Empty() {
+		t.Errorf("Applying the patch should give the target graph: %+v", p)
+	}
+	if !reflect.DeepEqual(a.EdgeList(), b.EdgeList()) {
+		t.Error("Applying the patch should keep the edge ids of the target graph")
+	}
+
+	if p, _ := Diff(b, b); !p.Empty() {
+		t.Error("A graph should not differ from itself")
+	}
+	if _, err := Diff(a, NewGraph(true)); err == nil {
+		t.Error("Diffing a directed and an undirected graph should fail")
+	}
+}
+
+func TestDiffParallelEdges(t *testing.T) {
+	// Snapshots built separately have unrelated edge ids
+	a := NewGraph(true)
+	a.InsertWeightedEdge(1, 2, 1, true)
+	a.InsertWeightedEdge(1, 2, 1, true)
+	a.InsertWeightedEdge(2, 3, 1, true)
+	a.InsertWeightedEdge(3, 4, 1, true)
+	b := NewGraph(true)
+	b.InsertWeightedEdge(3, 2, 1, true)
+	b.InsertWeightedEdge(2, 3, 5, true)
+	b.InsertWeightedEdge(1, 2, 1, true)
+
+	p, err := Diff(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.RemovedEdges) != 2 || len(p.AddedEdges) != 1 || len(p.ChangedEdges) != 1 {
+		t.Errorf("Incorrect edge changes: %+v", p)
+	}
+	if !reflect.DeepEqual(p.RemovedVertices, []int{4}) {
+		t.Errorf("Incorrect removed vertices: %v", p.RemovedVertices)
+	}
+	if err := a.Apply(p); err != nil {
+		t.Fatal(err)
+	}
+	if p, _ := Diff(a, b); !p.Empty() || a.NumVertices() != 3 || a.NumEdges() != 3 {
+		t.Errorf("Applying the patch should give the target graph: %+v", p)
+	}
+}
+
+func TestApplyRejectsMismatchedPatch(t *testing.T) {
+	g := initGraph(false)
+	before := g.EdgeList()
+	patches := []*Patch{
+		{RemovedEdges: []Edge{{ID: 100, X: 1, Y: 2}}},
+		{RemovedEdges: []Edge{{ID: before[0].ID, X: 9, Y: 10}}},
+		{ChangedEdges: []EdgeChange{{From: Edge{ID: 100, X: 1, Y: 2}}}},
+		{RemovedVertices: []int{10}},
+		{RemovedVertices: []int{1}, RemovedEdges: before},
+		{AddedVertices: []int{12}},
+		{AddedEdges: []Edge{{X: 1, Y: 11}}, ChangedEdges: []EdgeChange{{From: before[0], To: before[0]}}},
+		{VertexAttributes: map[int]Attributes{11: {"name": "k"}}},
+	}
+	for i, p := range patches {
+		if err := g.Apply(p); err == nil {
+			t.Errorf("Patch %d should not fit the graph", i)
+		}
+	}
+	if !reflect.DeepEqual(g.EdgeList(), before) || g.NumVertices() != 10 {
+		t.Error("A rejected patch should leave the graph unchanged")
+	}
+}
+
+func TestApplyToSimpleGraph(t *testing.T) {
+	g := NewSimpleGraph(false)
+	g.InsertEdge(1, 2, false)
+	g.InsertEdge(2, 3, false)
+	target := NewSimpleGraph(false)
+	target.InsertEdge(2, 3, false)
+	target.InsertEdge(1, 3, false)
+	p, _ := Diff(g, target)
+	if err := g.Apply(p); err != nil || !reflect.DeepEqual(sortedEdges(g), sortedEdges(target)) {
+		t.Errorf("Patch between simple graphs should apply: %v", err)
+	}
+
+	before := g.EdgeList()
+	patches := []*Patch{
+		{AddedEdges: []Edge{{X: 3, Y: 2}}},
+		{AddedEdges: []Edge{{X: 1, Y: 2}, {X: 2, Y: 1}}},
+		{AddedEdges: []Edge{{X: 2, Y: 2}}},
+	}
+	for i, p := range patches {
+		if err := g.Apply(p); err == nil {
+			t.Errorf("Patch %d should not add a parallel edge or self-loop", i)
+		}
+	}
+	if !reflect.DeepEqual(g.EdgeList(), before) {
+		t.Error("A rejected patch should leave the graph unchanged")
+	}
+	// An edge may be replaced by one between the same vertices
+	replace := &Patch{RemovedEdges: before[:1], AddedEdges: []Edge{{X: before[0].Y, Y: before[0].X, Weight: 4}}}
+	if err := g.Apply(replace); err != nil || g.NumEdges() != 2 {
+		t.Errorf("Replacing an edge should keep the graph simple: %v", err)
+	}
+}
+
+func TestRandomDiff(t *testing.T) {
+	r := rand.New(rand.NewSource(9))
+	for i := 0; i < 100; i++ {
+		a := randomWeightedGraph(r.Intn(12)+1, 0.3, r.Int63())
+		b := randomWeightedGraph(r.Intn(12)+1, 0.3, r.Int63())
+		if r.Intn(2) == 0 {
+			b.SetVertexAttribute(1, "color", "red")
+		}
+		p, err := Diff(a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := a.Apply(p); err != nil {
+			t.Fatalf("Graph %d: %v", i, err)
+		}
+		if p, _ := Diff(a, b); !p.Empty() {
+			t.Fatalf("Graph %d: applying the patch should give the target graph: %+v", i, p)
+		}
+	}
+}
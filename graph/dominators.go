@@ -0,0 +1,153 @@
+package graph
+
+import (
+	"errors"
+	"sort"
+)
+
+// A DominatorTree describes which vertices dominate which in a flow graph.
+// Every path from Entry to a vertex passes through all of its dominators.
+type DominatorTree struct {
+	Entry    int
+	IDom     map[int]int   // Immediate dominator of each reachable vertex (-1 for Entry)
+	Frontier map[int][]int // Dominance frontier of each reachable vertex
+}
+
+// PostOrderTraversal implements GraphProcessor in order to record the order in
+// which DFS finishes vertices
+type PostOrderTraversal struct {
+	Order []int
+}
+
+func (t *PostOrderTraversal) processVertexEarly(g *Graph, v int) {
+	// Do nothing here
+}
+
+func (t *PostOrderTraversal) processVertexLate(g *Graph, v int) {
+	t.Order = append(t.Order, v)
+}
+
+func (t *PostOrderTraversal) processEdge(g *Graph, x int, y int) {
+	// Do nothing here
+}
+
+// DominatorTree computes the immediate dominators and dominance frontiers of
+// a directed graph from entry using the iterative Cooper-Harvey-Kennedy
+// algorithm. Vertices unreachable from entry are left out.
+func (g *Graph) DominatorTree(entry int) (*DominatorTree, error) {
+	if !g.Directed {
+		return nil, errors.New("Dominators require a directed graph")
+	}
+	if entry < 1 || entry > g.nVertices {
+		return nil, errors.New("Entry vertex is not in the graph")
+	}
+	t := new(PostOrderTraversal)
+	g.InitSearch()
+	g.dfs(entry, t)
+	postOrder := make(map[int]int)
+	for i, v := range t.Order {
+		postOrder[v] = i
+	}
+
+	preds := make(map[int][]int)
+	for x, edgeNode := range g.Edges {
+		if _, reachable := postOrder[x]; !reachable {
+			continue
+		}
+		for ; edgeNode != nil; edgeNode = edgeNode.Next {
+			preds[edgeNode.Y] = append(preds[edgeNode.Y], x)
+		}
+	}
+
+	intersect := func(idom map[int]int, a, b int) int {
+		for a != b {
+			for postOrder[a] < postOrder[b] {
+				a = idom[a]
+			}
+			for postOrder[b] < postOrder[a] {
+				b = idom[b]
+			}
+		}
+		return a
+	}
+
+	idom := map[int]int{entry: entry}
+	for changed := true; changed; {
+		changed = false
+		// Reverse postorder so that most predecessors are settled first
+		for i := len(t.Order) - 2; i >= 0; i-- {
+			v := t.Order[i]
+			newIdom := -1
+			for _, p := range preds[v] {
+				if _, ok := idom[p]; !ok {
+					continue
+				}
+				if newIdom == -1 {
+					newIdom = p
+				} else {
+					newIdom = intersect(idom, p, newIdom)
+				}
+			}
+			if newIdom != -1 && idom[v] != newIdom {
+				idom[v] = newIdom
+				changed = true
+			}
+		}
+	}
+
+	// The entry has an implicit predecessor outside the graph, so it is a join
+	// point as soon as any edge leads back to it, and walks from those edges
+	// run up to and including the entry
+	idom[entry] = -1
+	frontier := make(map[int][]int)
+	for _, v := range t.Order {
+		frontier[v] = []int{}
+	}
+	for v, ps := range preds {
+		if len(ps) < 2 && v != entry {
+			continue
+		}
+		for _, p := range ps {
+			for runner := p; runner != idom[v]; runner = idom[runner] {
+				if !containsVertex(frontier[runner], v) {
+					frontier[runner] = append(frontier[runner], v)
+				}
+			}
+		}
+	}
+	for v := range frontier {
+		sort.Ints(frontier[v])
+	}
+	return &DominatorTree{Entry: entry, IDom: idom, Frontier: frontier}, nil
+}
+
+// PostDominatorTree computes post-dominators, the vertices every path from a
+// vertex to exit must pass through, as the dominators of the transposed graph
+func (g *Graph) PostDominatorTree(exit int) (*DominatorTree, error) {
+	if !g.Directed {
+		return nil, errors.New("Dominators require a directed graph")
+	}
+	return g.Transpose().DominatorTree(exit)
+}
+
+// Dominates reports whether a dominates b (every vertex dominates itself)
+func (d *DominatorTree) Dominates(a, b int) bool {
+	if _, ok := d.IDom[b]; !ok {
+		return false
+	}
+	for v := b; v != -1; v = d.IDom[v] {
+		if v == a {
+			return true
+		}
+	}
+	return false
+}
+
+func containsVertex(vertices []int, v int) bool {
+	for _, u := range vertices {
+		if u == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+// initFlowGraph builds a small control-flow graph with a loop from 5 back to 2
+// and a vertex 7 that is unreachable from the entry
+func initFlowGraph() *Graph {
+	g := NewGraph(true)
+	for _, e := range [][2]int{{1, 2}, {2, 3}, {2, 4}, {3, 5}, {4, 5}, {5, 6}, {5, 2}, {7, 6}} {
+		g.InsertEdge(e[0], e[1], true)
+	}
+	return g
+}
+
+func TestDominatorTree(t *testing.T) {
+	d, err := initFlowGraph().DominatorTree(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[int]int{1: -1, 2: 1, 3: 2, 4: 2, 5: 2, 6: 5}
+	if !reflect.DeepEqual(d.IDom, expected) {
+		t.Errorf("Incorrect immediate dominators: %v", d.IDom)
+	}
+	if !reflect.DeepEqual(d.Frontier[3], []int{5}) || !reflect.DeepEqual(d.Frontier[5], []int{2}) ||
+		!reflect.DeepEqual(d.Frontier[2], []int{2}) || len(d.Frontier[6]) != 0 {
+		t.Errorf("Incorrect dominance frontiers: %v", d.Frontier)
+	}
+	if !d.Dominates(2, 6) || d.Dominates(3, 5) || d.Dominates(1, 7) {
+		t.Error("Incorrect dominance relation")
+	}
+}
+
+func TestDominanceFrontierOfEntry(t *testing.T) {
+	// The loop 1 -> 2 -> 3 -> 1 returns to the entry, which joins the back
+	// edge with the implicit edge into the program
+	g := NewGraph(true)
+	for _, e := range [][2]int{{1, 2}, {2, 3}, {3, 1}, {2, 4}} {
+		g.InsertEdge(e[0], e[1], true)
+	}
+	d, err := g.DominatorTree(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[int][]int{1: {1}, 2: {1}, 3: {1}, 4: {}}
+	if !reflect.DeepEqual(d.Frontier, expected) {
+		t.Errorf("Incorrect dominance frontiers: %v", d.Frontier)
+	}
+	if !reflect.DeepEqual(d.IDom, map[int]int{1: -1, 2: 1, 3: 2, 4: 2}) {
+		t.Errorf("Incorrect immediate dominators: %v", d.IDom)
+	}
+}
+
+func TestPostDominatorTree(t *testing.T) {
+	g := initFlowGraph()
+	d, err := g.PostDominatorTree(6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[int]int{6: -1, 5: 6, 7: 6, 3: 5, 4: 5, 2: 5, 1: 2}
+	if !reflect.DeepEqual(d.IDom, expected) {
+		t.Errorf("Incorrect immediate post-dominators: %v", d.IDom)
+	}
+	if _, err := initGraph(false).DominatorTree(1); err == nil {
+		t.Error("Computed dominators of an undirected graph")
+	}
+}
@@ -0,0 +1,55 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WriteDOT writes the graph in the Graphviz DOT language. Every vertex is
+// listed so isolated ones show up too. Vertex and edge attributes become DOT
+// attributes, and non-zero weights label edges that have no label attribute.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	kind, arrow := "graph", "--"
+	if g.Directed {
+		kind, arrow = "digraph", "->"
+	}
+	fmt.Fprintf(&b, "%v {\n", kind)
+	for v := 1; v <= g.nVertices; v++ {
+		fmt.Fprintf(&b, "\t%v%v;\n", v, dotAttributes(g.vertexAttributes[v], nil))
+	}
+	for _, e := range g.EdgeList() {
+		var extra []string
+		if _, labelled := e.Attributes["label"]; e.Weight != 0 && !labelled {
+			extra = []string{"label", strconv.Itoa(e.Weight)}
+		}
+		fmt.Fprintf(&b, "\t%v %v %v%v;\n", e.X, arrow, e.Y, dotAttributes(e.Attributes, extra))
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dotAttributes formats the attributes, followed by the extra key/value
+// pairs, as a DOT attribute list, or returns "" when there are none
+func dotAttributes(a Attributes, extra []string) string {
+	keys := make([]string, 0, len(a))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := []string{}
+	for _, k := range keys {
+		pairs = append(pairs, strconv.Quote(k)+"="+strconv.Quote(a[k]))
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, extra[i]+"="+strconv.Quote(extra[i+1]))
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(pairs, ", ") + "]"
+}
@@ -0,0 +1,38 @@
+package graph
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteDOT(t *testing.T) {
+	g := NewGraph(true)
+	g.InsertWeightedEdge(1, 2, 3, true)
+	g.InsertEdgeWithAttributes(2, 3, 0, Attributes{"color": "red"}, true)
+	g.SetVertexAttribute(1, "label", "start")
+	g.InsertVertex(4)
+	buff := bytes.NewBuffer([]byte{})
+	if err := g.WriteDOT(buff); err != nil {
+		t.Fatal(err)
+	}
+	want := `digraph {
+	1 ["label"="start"];
+	2;
+	3;
+	4;
+	1 -> 2 [label="3"];
+	2 -> 3 ["color"="red"];
+}
+`
+	if buff.String() != want {
+		t.Errorf("Incorrect DOT output:\n%v", buff.String())
+	}
+
+	u := NewGraph(false)
+	u.InsertEdge(2, 1, false)
+	buff.Reset()
+	u.WriteDOT(buff)
+	if buff.String() != "graph {\n\t1;\n\t2;\n\t1 -- 2;\n}\n" {
+		t.Errorf("Incorrect undirected DOT output:\n%v", buff.String())
+	}
+}
@@ -0,0 +1,50 @@
+package graph
+
+// eulerianCircuit walks every edge of an undirected multigraph exactly once
+// using Hierholzer's algorithm, starting and ending at start. Every vertex
+// touched by edges must have even degree and the edges must be connected.
+// Along with the vertices it returns the index of each edge taken, so the
+// i-th edge joins the i-th and (i+1)-th vertices.
+func eulerianCircuit(edges [][2]int, start int) ([]int, []int) {
+	incident := make(map[int][]int) // edge ids touching each vertex
+	for id, e := range edges {
+		incident[e[0]] = append(incident[e[0]], id)
+		incident[e[1]] = append(incident[e[1]], id)
+	}
+	used := make([]bool, len(edges))
+	circuit, walked := []int{}, []int{}
+	stack := []int{start}
+	via := []int{-1} // Edge that led to each stack entry
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		// Drop edges already walked from the other endpoint
+		for len(incident[v]) > 0 && used[incident[v][len(incident[v])-1]] {
+			incident[v] = incident[v][:len(incident[v])-1]
+		}
+		if len(incident[v]) == 0 {
+			circuit = append(circuit, v)
+			if via[len(via)-1] != -1 {
+				walked = append(walked, via[len(via)-1])
+			}
+			stack = stack[:len(stack)-1]
+			via = via[:len(via)-1]
+			continue
+		}
+		id := incident[v][len(incident[v])-1]
+		used[id] = true
+		next := edges[id][0]
+		if next == v {
+			next = edges[id][1]
+		}
+		stack = append(stack, next)
+		via = append(via, id)
+	}
+	// Reverse so the walk reads in the order edges were taken
+	for i, j := 0, len(circuit)-1; i < j; i, j = i+1, j-1 {
+		circuit[i], circuit[j] = circuit[j], circuit[i]
+	}
+	for i, j := 0, len(walked)-1; i < j; i, j = i+1, j-1 {
+		walked[i], walked[j] = walked[j], walked[i]
+	}
+	return circuit, walked
+}
@@ -0,0 +1,162 @@
+package graph
+
+import "errors"
+
+// flowNetwork is a residual network stored as arrays of arcs. Arc i and arc
+// i^1 are the two directions of the same edge.
+type flowNetwork struct {
+	n    int
+	head []int // first arc leaving each vertex, or -1
+	next []int // next arc leaving the same vertex, or -1
+	to   []int
+	cap  []int // remaining capacity of each arc
+	cost []int // cost per unit of flow on each arc
+}
+
+func newFlowNetwork(n int) *flowNetwork {
+	f := &flowNetwork{n: n, head: make([]int, n+1)}
+	for i := range f.head {
+		f.head[i] = -1
+	}
+	return f
+}
+
+// addArc adds an arc from x to y with the given capacity, paired with a
+// reverse arc of capacity reverseCap. It returns the index of the forward arc.
+func (f *flowNetwork) addArc(x, y, capacity, reverseCap int) int {
+	return f.addCostArc(x, y, capacity, reverseCap, 0)
+}
+
+// addCostArc is addArc for arcs carrying a cost per unit of flow; the reverse
+// arc refunds that cost
+func (f *flowNetwork) addCostArc(x, y, capacity, reverseCap, cost int) int {
+	for _, a := range [][3]int{{x, y, capacity}, {y, x, reverseCap}} {
+		f.to = append(f.to, a[1])
+		f.cap = append(f.cap, a[2])
+		f.next = append(f.next, f.head[a[0]])
+		f.head[a[0]] = len(f.to) - 1
+	}
+	f.cost = append(f.cost, cost, -cost)
+	return len(f.to) - 2
+}
+
+// capacityNetwork builds a residual network from the graph using edge weights
+// as capacities. Undirected edges can carry flow either way.
+func (g *Graph) capacityNetwork() (*flowNetwork, error) {
+	f := newFlowNetwork(g.nVertices)
+	for _, e := range g.EdgeList() {
+		if e.Weight < 0 {
+			return nil, errors.New("Edge capacities must not be negative")
+		}
+		if g.Directed {
+			f.addArc(e.X, e.Y, e.Weight, 0)
+		} else {
+			f.addArc(e.X, e.Y, e.Weight, e.Weight)
+		}
+	}
+	return f, nil
+}
+
+// maxFlow pushes as much flow as possible from source to sink with Dinic's
+// algorithm: blocking flows along BFS levels of the residual network
+func (f *flowNetwork) maxFlow(source, sink int) int {
+	total := 0
+	level := make([]int, f.n+1)
+	iter := make([]int, f.n+1)
+	for {
+		f.levels(source, level)
+		if level[sink] < 0 {
+			return total
+		}
+		copy(iter, f.head)
+		for {
+			pushed := f.augment(source, sink, maxCapacity, level, iter)
+			if pushed == 0 {
+				break
+			}
+			total += pushed
+		}
+	}
+}
+
+// maxCapacity stands in for an unbounded amount of flow
+const maxCapacity = int(^uint(0) >> 1)
+
+// levels labels vertices with their BFS distance from source in the residual
+// network, or -1 when they cannot be reached
+func (f *flowNetwork) levels(source int, level []int) {
+	for i := range level {
+		level[i] = -1
+	}
+	level[source] = 0
+	queue := []int{source}
+	for head := 0; head < len(queue); head++ {
+		v := queue[head]
+		for a := f.head[v]; a != -1; a = f.next[a] {
+			if f.cap[a] > 0 && level[f.to[a]] < 0 {
+				level[f.to[a]] = level[v] + 1
+				queue = append(queue, f.to[a])
+			}
+		}
+	}
+}
+
+// augment finds one path along increasing levels and pushes flow through it.
+// iter remembers the next arc to try from each vertex so dead ends are skipped.
+func (f *flowNetwork) augment(v, sink, limit int, level, iter []int) int {
+	if v == sink {
+		return limit
+	}
+	for ; iter[v] != -1; iter[v] = f.next[iter[v]] {
+		a := iter[v]
+		y := f.to[a]
+		if f.cap[a] <= 0 || level[y] != level[v]+1 {
+			continue
+		}
+		bottleneck := limit
+		if f.cap[a] < bottleneck {
+			bottleneck = f.cap[a]
+		}
+		if pushed := f.augment(y, sink, bottleneck, level, iter); pushed > 0 {
+			f.cap[a] -= pushed
+			f.cap[a^1] += pushed
+			return pushed
+		}
+	}
+	return 0
+}
+
+// reachable returns the vertices reachable from source through arcs with
+// remaining capacity, which after a max flow is the source side of a min cut
+func (f *flowNetwork) reachable(source int) []int {
+	seen := make([]bool, f.n+1)
+	seen[source] = true
+	side := []int{source}
+	for head := 0; head < len(side); head++ {
+		for a := f.head[side[head]]; a != -1; a = f.next[a] {
+			if f.cap[a] > 0 && !seen[f.to[a]] {
+				seen[f.to[a]] = true
+				side = append(side, f.to[a])
+			}
+		}
+	}
+	return side
+}
+
+// MaxFlow computes the maximum flow from source to sink, treating edge weights
+// as capacities. It also returns the source side of a minimum source-sink cut,
+// whose capacity equals the flow.
+func (g *Graph) MaxFlow(source, sink int) (int, []int, error) {
+	if source < 1 || source > g.nVertices || sink < 1 || sink > g.nVertices {
+		return 0, nil, errors.New("Source or sink is not in the graph")
+	}
+	if source == sink {
+		return 0, nil, errors.New("Source and sink must differ")
+	}
+	f, err := g.capacityNetwork()
+	if err != nil {
+		return 0, nil, err
+	}
+	value := f.maxFlow(source, sink)
+	return value, f.reachable(source), nil
+}
@@ -0,0 +1,33 @@
+package graph
+
+import (
+	"sort"
+	"testing"
+)
+
+// initFlowNetwork builds the classic CLRS flow network with a maximum flow
+// of 23 from vertex 1 to vertex 6
+func initFlowNetwork() *Graph {
+	g := NewGraph(true)
+	for _, e := range [][3]int{{1, 2, 16}, {1, 3, 13}, {2, 4, 12}, {3, 2, 4}, {3, 5, 14}, {4, 3, 9}, {4, 6, 20}, {5, 4, 7}, {5, 6, 4}} {
+		g.InsertWeightedEdge(e[0], e[1], e[2], true)
+	}
+	return g
+}
+
+func TestMaxFlow(t *testing.T) {
+	value, side, err := initFlowNetwork().MaxFlow(1, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 23 {
+		t.Errorf("Incorrect maximum flow %v", value)
+	}
+	sort.Ints(side)
+	if len(side) != 4 || side[0] != 1 || side[3] != 5 {
+		t.Errorf("Incorrect minimum cut side %v", side)
+	}
+	if _, _, err := initFlowNetwork().MaxFlow(1, 1); err == nil {
+		t.Error("Accepted identical source and sink")
+	}
+}
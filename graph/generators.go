@@ -0,0 +1,261 @@
+package graph
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+)
+
+// Generators build undirected simple graphs on the vertices 1..n. The random
+// ones draw from the caller's rand.Source so that results are reproducible.
+
+// edgeSet collects undirected edges without duplicates before they are
+// inserted into a graph
+type edgeSet map[[2]int]bool
+
+func (s edgeSet) add(x, y int) bool {
+	if x == y || s.has(x, y) {
+		return false
+	}
+	if x > y {
+		x, y = y, x
+	}
+	s[[2]int{x, y}] = true
+	return true
+}
+
+func (s edgeSet) has(x, y int) bool {
+	if x > y {
+		x, y = y, x
+	}
+	return s[[2]int{x, y}]
+}
+
+func (s edgeSet) remove(x, y int) {
+	if x > y {
+		x, y = y, x
+	}
+	delete(s, [2]int{x, y})
+}
+
+// build creates a graph on n vertices holding the edges in ascending order
+func (s edgeSet) build(n int) *Graph {
+	sorted := make([][2]int, 0, len(s))
+	for e := range s {
+		sorted = append(sorted, e)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i][0] != sorted[j][0] {
+			return sorted[i][0] < sorted[j][0]
+		}
+		return sorted[i][1] < sorted[j][1]
+	})
+	g := NewSimpleGraph(false)
+	g.InsertVertex(n)
+	for _, e := range sorted {
+		g.InsertEdge(e[0], e[1], false)
+	}
+	return g
+}
+
+// ErdosRenyi generates a G(n, p) random graph in which each of the possible
+// edges is present independently with probability p
+func ErdosRenyi(n int, p float64, src rand.Source) *Graph {
+	r := rand.New(src)
+	g := NewSimpleGraph(false)
+	g.InsertVertex(n)
+	for x := 1; x <= n; x++ {
+		for y := x + 1; y <= n; y++ {
+			if r.Float64() < p {
+				g.InsertEdge(x, y, false)
+			}
+		}
+	}
+	return g
+}
+
+// ErdosRenyiM generates a G(n, m) random graph with exactly m edges chosen
+// uniformly among all possible edges
+func ErdosRenyiM(n, m int, src rand.Source) (*Graph, error) {
+	if n < 0 {
+		return nil, errors.New("Vertex count must not be negative")
+	}
+	if m < 0 || m > n*(n-1)/2 {
+		return nil, errors.New("Too many edges for the number of vertices")
+	}
+	r := rand.New(src)
+	edges := make(edgeSet)
+	for len(edges) < m {
+		edges.add(r.Intn(n)+1, r.Intn(n)+1)
+	}
+	return edges.build(n), nil
+}
+
+// BarabasiAlbert generates a scale-free graph by preferential attachment. It
+// starts from a star on m+1 vertices and links every further vertex to m
+// distinct existing vertices chosen with probability proportional to degree.
+func BarabasiAlbert(n, m int, src rand.Source) (*Graph, error) {
+	if m < 1 || m >= n {
+		return nil, errors.New("Attachment count must be between 1 and n-1")
+	}
+	r := rand.New(src)
+	edges := make(edgeSet)
+	repeated := []int{} // each vertex appears once per incident edge
+	for y := 2; y <= m+1; y++ {
+		edges.add(1, y)
+		repeated = append(repeated, 1, y)
+	}
+	for v := m + 2; v <= n; v++ {
+		chosen := make(map[int]bool)
+		targets := []int{}
+		for len(targets) < m {
+			y := repeated[r.Intn(len(repeated))]
+			if !chosen[y] {
+				chosen[y] = true
+				targets = append(targets, y)
+			}
+		}
+		for _, y := range targets {
+			edges.add(v, y)
+			repeated = append(repeated, v, y)
+		}
+	}
+	return edges.build(n), nil
+}
+
+// WattsStrogatz generates a small-world graph. Each vertex of a ring is joined
+// to its k nearest neighbours, then each of those edges is rewired to a random
+// endpoint with probability beta.
+func WattsStrogatz(n, k int, beta float64, src rand.Source) (*Graph, error) {
+	if k%2 != 0 || k < 2 || k >= n {
+		return nil, errors.New("Neighbour count must be even and between 2 and n-1")
+	}
+	r := rand.New(src)
+	edges := make(edgeSet)
+	for x := 1; x <= n; x++ {
+		for j := 1; j <= k/2; j++ {
+			edges.add(x, (x+j-1)%n+1)
+		}
+	}
+	for j := 1; j <= k/2; j++ {
+		for x := 1; x <= n; x++ {
+			y := (x+j-1)%n + 1
+			if r.Float64() >= beta || !edges.has(x, y) {
+				continue
+			}
+			z := r.Intn(n) + 1
+			if z == x || edges.has(x, z) {
+				continue // keep the original edge rather than create a duplicate
+			}
+			edges.remove(x, y)
+			edges.add(x, z)
+		}
+	}
+	return edges.build(n), nil
+}
+
+// GridGraph generates a rows x cols lattice numbered row by row
+func GridGraph(rows, cols int) *Graph {
+	edges := make(edgeSet)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			v := i*cols + j + 1
+			if j+1 < cols {
+				edges.add(v, v+1)
+			}
+			if i+1 < rows {
+				edges.add(v, v+cols)
+			}
+		}
+	}
+	return edges.build(rows * cols)
+}
+
+// CompleteGraph generates the graph with an edge between every pair of vertices
+func CompleteGraph(n int) *Graph {
+	edges := make(edgeSet)
+	for x := 1; x <= n; x++ {
+		for y := x + 1; y <= n; y++ {
+			edges.add(x, y)
+		}
+	}
+	return edges.build(n)
+}
+
+// CompleteBipartiteGraph joins each of the vertices 1..a to each of the
+// vertices a+1..a+b
+func CompleteBipartiteGraph(a, b int) *Graph {
+	edges := make(edgeSet)
+	for x := 1; x <= a; x++ {
+		for y := a + 1; y <= a+b; y++ {
+			edges.add(x, y)
+		}
+	}
+	return edges.build(a + b)
+}
+
+// StarGraph joins vertex 1 to each of the vertices 2..n
+func StarGraph(n int) *Graph {
+	edges := make(edgeSet)
+	for y := 2; y <= n; y++ {
+		edges.add(1, y)
+	}
+	return edges.build(n)
+}
+
+// PathGraph joins the vertices 1..n in a line
+func PathGraph(n int) *Graph {
+	edges := make(edgeSet)
+	for x := 1; x < n; x++ {
+		edges.add(x, x+1)
+	}
+	return edges.build(n)
+}
+
+// CycleGraph joins the vertices 1..n in a ring
+func CycleGraph(n int) *Graph {
+	g := PathGraph(n)
+	if n > 2 {
+		g.InsertEdge(n, 1, false)
+	}
+	return g
+}
+
+// RandomTree generates a uniformly random labelled tree on n vertices by
+// decoding a random Prüfer sequence
+func RandomTree(n int, src rand.Source) *Graph {
+	if n < 2 {
+		g := NewSimpleGraph(false)
+		g.InsertVertex(n)
+		return g
+	}
+	r := rand.New(src)
+	prufer := make([]int, n-2)
+	degree := make([]int, n+1)
+	for i := 1; i <= n; i++ {
+		degree[i] = 1
+	}
+	for i := range prufer {
+		prufer[i] = r.Intn(n) + 1
+		degree[prufer[i]]++
+	}
+	edges := make(edgeSet)
+	for _, v := range prufer {
+		for leaf := 1; leaf <= n; leaf++ {
+			if degree[leaf] == 1 {
+				edges.add(leaf, v)
+				degree[leaf]--
+				degree[v]--
+				break
+			}
+		}
+	}
+	last := []int{}
+	for i := 1; i <= n; i++ {
+		if degree[i] == 1 {
+			last = append(last, i)
+		}
+	}
+	edges.add(last[0], last[1])
+	return edges.build(n)
+}
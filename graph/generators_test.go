@@ -0,0 +1,81 @@
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestStructuredGenerators(t *testing.T) {
+	cases := []struct {
+		name      string
+		g         *Graph
+		vertices  int
+		edges     int
+		maxDegree int
+	}{
+		{"grid", GridGraph(3, 4), 12, 17, 4},
+		{"complete", CompleteGraph(5), 5, 10, 4},
+		{"bipartite", CompleteBipartiteGraph(2, 3), 5, 6, 3},
+		{"star", StarGraph(6), 6, 5, 5},
+		{"path", PathGraph(4), 4, 3, 2},
+		{"cycle", CycleGraph(5), 5, 5, 2},
+	}
+	for _, c := range cases {
+		if c.g.NumVertices() != c.vertices || c.g.NumEdges() != c.edges {
+			t.Errorf("%v: got %v vertices and %v edges", c.name, c.g.NumVertices(), c.g.NumEdges())
+		}
+		if !c.g.Simple() {
+			t.Errorf("%v: generated graph is not simple", c.name)
+		}
+		for v, d := range c.g.Degree {
+			if d > c.maxDegree {
+				t.Errorf("%v: vertex %v has degree %v", c.name, v, d)
+			}
+		}
+	}
+}
+
+func TestRandomGenerators(t *testing.T) {
+	g := ErdosRenyi(50, 0.1, rand.NewSource(1))
+	if g.NumVertices() != 50 {
+		t.Error("G(n, p) has the wrong number of vertices")
+	}
+	if !reflect.DeepEqual(g.Degree, ErdosRenyi(50, 0.1, rand.NewSource(1)).Degree) {
+		t.Error("Same seed produced different graphs")
+	}
+
+	g, err := ErdosRenyiM(20, 30, rand.NewSource(1))
+	if err != nil || g.NumEdges() != 30 {
+		t.Error("G(n, m) has the wrong number of edges")
+	}
+	if _, err := ErdosRenyiM(4, 7, rand.NewSource(1)); err == nil {
+		t.Error("Accepted more edges than a simple graph can hold")
+	}
+	if _, err := ErdosRenyiM(-3, 2, rand.NewSource(1)); err == nil {
+		t.Error("Accepted a negative number of vertices")
+	}
+
+	g, err = BarabasiAlbert(30, 2, rand.NewSource(1))
+	if err != nil || g.NumEdges() != 2+(30-3)*2 {
+		t.Error("Barabasi-Albert graph has the wrong number of edges")
+	}
+
+	g, err = WattsStrogatz(20, 4, 0.3, rand.NewSource(1))
+	if err != nil || g.NumEdges() != 40 {
+		t.Error("Watts-Strogatz rewiring changed the number of edges")
+	}
+
+	g = RandomTree(25, rand.NewSource(1))
+	if g.NumEdges() != 24 || len(g.ConnectedComponents()) != 1 {
+		t.Error("Random tree is not a spanning tree")
+	}
+
+	// A parallel edge inserted later merges into the existing one
+	g = ErdosRenyi(10, 0.5, rand.NewSource(1))
+	e := g.EdgeList()[0]
+	g.InsertEdge(e.Y, e.X, false)
+	if !g.Simple() || len(g.EdgeIDs(e.X, e.Y)) != 1 {
+		t.Error("Generated graphs should stay simple")
+	}
+}
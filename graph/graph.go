@@ -1,16 +1,18 @@
 package graph
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/fabioberger/data-structures/queue"
 )
 
-var Output io.Writer = os.Stdout
-
 // Enum of Vertice States (i.e DISCOVERED, UNDISCOVERED, PROCESSED)
 type VerticeState int
 
@@ -40,15 +42,34 @@ type GraphProcessor interface {
 
 // An EdgeNode represents a singe vertice of a graphs adjacency list
 type EdgeNode struct {
-	Y      int
-	Weight int
-	Next   *EdgeNode
+	ID         int // Shared by both directions of an undirected edge
+	Y          int
+	Weight     int
+	Attributes Attributes // Shared by both directions of an undirected edge
+	Next       *EdgeNode
 }
 
 // A Graph contains all the data structures necessary to describe the properties of a Graph
+//
+// Vertices are numbered 1..n, where n is the largest vertex id passed to
+// InsertEdge, InsertVertex or any other insertion so far. Ids below n that no
+// edge touches are isolated vertices, so a file listing only the edge 1-5 has
+// five vertices, not two. Traversals, NumVertices and every algorithm that
+// loops over the vertices follow this numbering.
+//
+// Graphs are multigraphs unless created with NewSimpleGraph. In a multigraph
+// every inserted edge is kept, gets its own id and can be weighted or removed
+// on its own:
+//
+//   - Degree counts adjacency list entries, so every parallel edge counts and
+//     an undirected self-loop counts twice. For directed graphs it is the
+//     out-degree.
+//   - FindCycles reports a self-loop as a cycle of length one and a pair of
+//     parallel edges as a cycle of length two.
+//   - ConnectedComponents is not affected by parallel edges or self-loops.
 type Graph struct {
 	Edges             map[int]*EdgeNode    //Adjacency list of edges
-	Degree            map[int]int          // degree of each edge
+	Degree            map[int]int          // Adjacency list length of each vertex
 	nVertices         int                  // Number of vertices
 	nEdges            int                  // Number of Edges
 	Directed          bool                 // Is the graph directed or undirected?
@@ -59,8 +80,13 @@ type Graph struct {
 	ExitTime          map[int]int          // Time when vertices were exited
 	ReachableAncestor map[int]int          // Earliest reachable ancestor of a vertice
 	TreeOutDegree     map[int]int
-	Finished          bool  // Graph traversal end condition reached
-	Path              []int // Contains shortest path if one calculated
+	Finished          bool               // Graph traversal end condition reached
+	Path              []int              // Contains shortest path if one calculated
+	vertexAttributes  map[int]Attributes // Labels and metadata of each vertex
+	simple            bool               // Reject parallel edges and self-loops
+	lastEdgeID        int                // Largest edge id handed out so far
+	edgeEnds          map[int][2]int     // Endpoints of each edge by id
+	logger            *slog.Logger       // Receives diagnostics, nil to stay silent
 }
 
 // NewGraph instantiates a new Graph struct with sensible default values
@@ -72,42 +98,184 @@ func NewGraph(directed bool) *Graph {
 	g.Finished = false
 	g.Edges = make(map[int]*EdgeNode)
 	g.Degree = make(map[int]int)
+	g.vertexAttributes = make(map[int]Attributes)
+	g.edgeEnds = make(map[int][2]int)
+	return g
+}
+
+// NewSimpleGraph instantiates a Graph that keeps at most one edge between any
+// two vertices and no self-loops. Inserting an edge that already exists
+// updates its weight and attributes instead, and self-loops are ignored.
+func NewSimpleGraph(directed bool) *Graph {
+	g := NewGraph(directed)
+	g.simple = true
 	return g
 }
 
+// Simple reports whether the graph rejects parallel edges and self-loops
+func (g *Graph) Simple() bool {
+	return g.simple
+}
+
 // InsertEdge adds a node to the adjacency list of the Graph and updates
 // the necessary edge and degree counts
 // x is adjacent edge to y which is the Id of the new edge being inserted
 func (g *Graph) InsertEdge(x, y int, directed bool) {
+	g.InsertWeightedEdge(x, y, 0, directed)
+}
+
+// InsertWeightedEdge adds an edge from x to y carrying the given weight
+func (g *Graph) InsertWeightedEdge(x, y, weight int, directed bool) {
+	g.InsertEdgeWithAttributes(x, y, weight, nil, directed)
+}
+
+// InsertEdgeWithAttributes adds an edge from x to y carrying the given weight
+// and attributes. Both directions of an undirected edge share the attributes.
+func (g *Graph) InsertEdgeWithAttributes(x, y, weight int, attributes Attributes, directed bool) {
+	if g.simple {
+		if x == y {
+			return
+		}
+		if existing := g.findEdge(x, y); existing != nil {
+			g.SetEdgeWeight(existing.ID, weight)
+			for key, value := range attributes {
+				g.SetEdgeAttributeByID(existing.ID, key, value)
+			}
+			return
+		}
+	}
+	g.insertEdge(0, x, y, weight, attributes, directed)
+}
+
+// AddEdge inserts an edge from x to y, in both directions if the graph is
+// undirected, and returns its id. Simple graphs return an error instead of
+// adding a parallel edge or a self-loop.
+func (g *Graph) AddEdge(x, y, weight int) (int, error) {
+	if x < 1 || y < 1 {
+		return 0, errors.New("Vertices are numbered from 1")
+	}
+	if g.simple && x == y {
+		return 0, errors.New("Simple graphs cannot have self-loops")
+	}
+	if g.simple && g.findEdge(x, y) != nil {
+		return 0, errors.New("Edge already exists")
+	}
+	return g.insertEdge(0, x, y, weight, nil, g.Directed), nil
+}
+
+// insertEdge links a new edge into the adjacency lists under the given id, or
+// under a fresh one if id is 0 or already taken, and returns the id used
+func (g *Graph) insertEdge(id, x, y, weight int, attributes Attributes, directed bool) int {
+	if g.edgeEnds == nil {
+		g.edgeEnds = make(map[int][2]int)
+	}
+	if _, taken := g.edgeEnds[id]; id <= 0 || taken {
+		id = g.lastEdgeID + 1
+	}
+	if id > g.lastEdgeID {
+		g.lastEdgeID = id
+	}
+	g.edgeEnds[id] = [2]int{x, y}
+	g.nEdges++
+	g.linkEdgeNode(id, x, y, weight, attributes)
+	if !directed {
+		g.linkEdgeNode(id, y, x, weight, attributes)
+	}
+	return id
+}
+
+// linkEdgeNode prepends an entry for y to the adjacency list of x
+func (g *Graph) linkEdgeNode(id, x, y, weight int, attributes Attributes) {
 	p := new(EdgeNode)
-	p.Weight = 0
+	p.ID = id
+	p.Weight = weight
+	p.Attributes = attributes
 	p.Y = y // value of the new adjacent vertex to x
 	p.Next = g.Edges[x]
 
 	g.Edges[x] = p
 	g.Degree[x]++
+	g.trackVertex(x)
+	g.trackVertex(y)
+}
 
-	if directed == false {
-		g.InsertEdge(y, x, true)
-	} else {
-		g.nEdges++
+// InsertVertex adds v to the graph even if no edge touches it, along with any
+// lower numbered vertices not yet in the graph
+func (g *Graph) InsertVertex(v int) {
+	g.trackVertex(v)
+}
+
+// trackVertex grows the vertex count so that vertices are always numbered 1..n
+func (g *Graph) trackVertex(v int) {
+	if v > g.nVertices {
+		g.nVertices = v
+	}
+}
+
+// NumVertices returns the number of vertices in the graph, which is the
+// largest vertex id inserted so far
+func (g *Graph) NumVertices() int {
+	return g.nVertices
+}
+
+// NumEdges returns the number of edges in the graph
+func (g *Graph) NumEdges() int {
+	return g.nEdges
+}
+
+// undirectedNeighbors returns the set of neighbours of every vertex, ignoring
+// edge direction, parallel edges and self-loops
+func (g *Graph) undirectedNeighbors() map[int]map[int]bool {
+	adj := make(map[int]map[int]bool)
+	for i := 1; i <= g.nVertices; i++ {
+		adj[i] = make(map[int]bool)
+	}
+	for x, edgeNode := range g.Edges {
+		for ; edgeNode != nil; edgeNode = edgeNode.Next {
+			if x == edgeNode.Y {
+				continue
+			}
+			adj[x][edgeNode.Y] = true
+			adj[edgeNode.Y][x] = true
+		}
 	}
+	return adj
+}
+
+// SetLogger routes diagnostics such as found paths, cycles and articulation
+// vertices to the given logger. Graphs are silent until a logger is set, and
+// passing nil silences them again.
+func (g *Graph) SetLogger(logger *slog.Logger) {
+	g.logger = logger
 }
 
-// Print outputs a representation of the Graph based on its adjacency list
+// log reports a diagnostic message if the graph has a logger
+func (g *Graph) log(msg string, args ...any) {
+	if g.logger != nil {
+		g.logger.Info(msg, args...)
+	}
+}
+
+// Print writes a representation of the Graph based on its adjacency list to
+// standard output
 func (g *Graph) Print() {
-	fmt.Fprintf(Output, "Graph num edges: %v and num vertices: %v \n", g.nEdges, g.nVertices)
-	fmt.Fprintf(Output, "Directed? %v\n", g.Directed)
-	fmt.Fprintf(Output, "Adjacency List:\n")
+	g.Fprint(os.Stdout)
+}
+
+// Fprint writes a representation of the Graph based on its adjacency list to w
+func (g *Graph) Fprint(w io.Writer) {
+	fmt.Fprintf(w, "Graph num edges: %v and num vertices: %v \n", g.nEdges, g.nVertices)
+	fmt.Fprintf(w, "Directed? %v\n", g.Directed)
+	fmt.Fprintf(w, "Adjacency List:\n")
 	var temp *EdgeNode
 	for i := 1; i <= g.nVertices; i++ {
-		fmt.Fprintf(Output, "Vert. %v ->", i)
+		fmt.Fprintf(w, "Vert. %v ->", i)
 		temp = g.Edges[i]
 		for temp != nil {
-			fmt.Fprintf(Output, " %v", temp.Y)
+			fmt.Fprintf(w, " %v", temp.Y)
 			temp = temp.Next
 		}
-		fmt.Fprintln(Output, "")
+		fmt.Fprintln(w, "")
 	}
 }
 
@@ -120,25 +288,37 @@ func (g *Graph) Read(fileName string) {
 		panic(err.Error())
 	}
 	defer file.Close()
+	if err := g.Load(file); err != nil {
+		panic(err.Error())
+	}
+}
 
-	seenVertices := make(map[int]bool)
-
-	var x, y int
-	for {
-		_, err := fmt.Fscanf(file, "%v %v", &x, &y)
-		if err == io.EOF {
-			break
+// Load inserts the edges listed in r, one per line as two vertices and an
+// optional weight. Blank lines and lines starting with # are skipped.
+func (g *Graph) Load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
 		}
-		g.InsertEdge(x, y, g.Directed)
-		if _, ok := seenVertices[x]; !ok {
-			seenVertices[x] = true
-			g.nVertices++
+		if len(fields) > 3 {
+			return fmt.Errorf("Line %v: expected two vertices and an optional weight", line)
 		}
-		if _, ok := seenVertices[y]; !ok {
-			seenVertices[y] = true
-			g.nVertices++
+		values := make([]int, 3)
+		for i, field := range fields {
+			value, err := strconv.Atoi(field)
+			if err != nil {
+				return fmt.Errorf("Line %v: %q is not a number", line, field)
+			}
+			values[i] = value
+		}
+		if len(fields) < 2 || values[0] < 1 || values[1] < 1 {
+			return fmt.Errorf("Line %v: expected two vertices numbered from 1", line)
 		}
+		g.InsertWeightedEdge(values[0], values[1], values[2], g.Directed)
 	}
+	return scanner.Err()
 }
 
 // InitSearch re-initializes the State and Parent relationships of the graph vertices
@@ -216,26 +396,25 @@ func (g *Graph) FindPath(start, end int) ([]int, error) {
 	g.bfs(start, t)
 	err := g.traversePath(start, end)
 	if err != nil {
+		g.log("No path exists", "start", start, "end", end)
 		return nil, err
 	}
+	g.log("Path found", "start", start, "end", end, "path", g.Path)
 	return g.Path, nil
 }
 
-// Traverse the shortest path between two nodes recursively printing the path
+// Traverse the shortest path between two nodes recursively collecting the path
 func (g *Graph) traversePath(start, end int) error {
 	if g.Parent[end] == -1 && start != end { // Must make sure a path is possible
-		fmt.Fprintln(Output, "No path exists...")
 		return errors.New("No Path exists")
 	}
 	if start == end || end == -1 {
 		g.Path = append(g.Path, start)
-		fmt.Fprintf(Output, "%v", start)
 	} else {
 		if err := g.traversePath(start, g.Parent[end]); err != nil {
 			return err
 		}
 		g.Path = append(g.Path, end)
-		fmt.Fprintf(Output, " %v", end)
 	}
 	return nil
 }
@@ -295,7 +474,8 @@ func (t *ConnectedComponentTraversal) processEdge(g *Graph, x int, y int) {
 	// Do nothing here
 }
 
-// ConnectedComponents discovers all connected components of a graph
+// ConnectedComponents discovers all connected components of a graph.
+// Parallel edges and self-loops do not affect the components.
 func (g *Graph) ConnectedComponents() map[int][]int {
 	t := NewConnectedComponentTraversal()
 	g.InitSearch()
@@ -313,7 +493,8 @@ func (g *Graph) ConnectedComponents() map[int][]int {
 // CycleFindTraversal implements GraphProcessor in order to find graph cycles
 // with the help of DFS
 type CycleFindTraversal struct {
-	CycleEdge [2]int
+	CycleEdge      [2]int
+	parentEdgeSeen map[int]bool // Vertices whose tree edge was already followed back to the parent
 }
 
 func (t *CycleFindTraversal) processVertexEarly(g *Graph, v int) {
@@ -325,12 +506,27 @@ func (t *CycleFindTraversal) processVertexLate(g *Graph, v int) {
 }
 
 func (t *CycleFindTraversal) processEdge(g *Graph, x int, y int) {
+	if g.Directed && g.edgeClassification(x, y) != BACK {
+		return // Forward and cross edges do not close a cycle
+	}
+	if !g.Directed && g.Parent[x] == y && !t.parentEdgeSeen[x] {
+		// The tree edge seen from the child. Any further edge to the parent
+		// is a parallel edge and closes a cycle of length two.
+		if t.parentEdgeSeen == nil {
+			t.parentEdgeSeen = make(map[int]bool)
+		}
+		t.parentEdgeSeen[x] = true
+		return
+	}
 	if g.Parent[y] != x { // Found back edge
 		t.CycleEdge = [2]int{y, x}
-		fmt.Fprintf(Output, "Cycle exists from %v to %v \n", y, x)
-		fmt.Fprintf(Output, "Path is: ")
-		g.FindPath(y, x)
-		fmt.Fprintf(Output, "\n\n")
+		// The tree path from y down to x closes the cycle
+		g.Path = []int{}
+		for v := x; v != y; v = g.Parent[v] {
+			g.Path = append([]int{v}, g.Path...)
+		}
+		g.Path = append([]int{y}, g.Path...)
+		g.log("Cycle found", "from", y, "to", x, "path", g.Path)
 		g.Finished = true
 	}
 }
@@ -372,20 +568,20 @@ func (t *ArticulationVectorTraversal) processEdge(g *Graph, x int, y int) {
 func (t *ArticulationVectorTraversal) processVertexLate(g *Graph, v int) {
 	if g.Parent[v] == -1 { // Test if v is root
 		if g.TreeOutDegree[v] > 1 { // root has more then one child
-			fmt.Fprintln(Output, "Root articulation vertex: ", v)
+			g.log("Articulation vertex found", "kind", "root", "vertex", v)
 			t.ArticulationVectors = append(t.ArticulationVectors, v)
 		}
 		return
 	}
 	root := (g.Parent[g.Parent[v]] < 1) // Is the parent of v the root vertex?
 	if g.ReachableAncestor[v] == g.Parent[v] && !root {
-		fmt.Fprintln(Output, "Parent Articulation Vector: ", g.Parent[v])
+		g.log("Articulation vertex found", "kind", "parent", "vertex", g.Parent[v])
 		t.ArticulationVectors = append(t.ArticulationVectors, g.Parent[v])
 	}
 	if g.ReachableAncestor[v] == v {
 		// fmt.Println("Bridge Articulation Vertex: ", g.Parent[v])
 		if g.TreeOutDegree[v] > 0 { // Check that v is not a leaf
-			fmt.Fprintln(Output, "Bridge Articulation Vertex: ", v)
+			g.log("Articulation vertex found", "kind", "bridge", "vertex", v)
 			t.ArticulationVectors = append(t.ArticulationVectors, v)
 		}
 	}
@@ -2,7 +2,7 @@ package graph
 
 import (
 	"bytes"
-	"os"
+	"log/slog"
 	"reflect"
 	"strings"
 	"testing"
@@ -22,7 +22,6 @@ func TestFindConnectedComponents(t *testing.T) {
 }
 
 func TestArticulationVectors(t *testing.T) {
-	silenceOutput()
 	g := initGraph(true)
 	g.InitSearch()
 	got := g.FindArticulationVectors(1)
@@ -93,8 +92,8 @@ func TestDepthFirstSearch(t *testing.T) {
 
 func TestPrint(t *testing.T) {
 	g := initGraph(true)
-	buff := switchToBuffer()
-	g.Print()
+	buff := bytes.NewBuffer([]byte{})
+	g.Fprint(buff)
 	lines := strings.Split(buff.String(), "\n")
 	// This is not a comprehensive test for graph printing since the output is
 	// not is a very standard format. To be improved.
@@ -106,21 +105,87 @@ func TestPrint(t *testing.T) {
 	}
 }
 
-func initGraph(directed bool) *Graph {
-	g := NewGraph(directed)
-	g.Read("./test_data/graph1.txt")
-	return g
+func TestLogger(t *testing.T) {
+	g := initGraph(true)
+	buff := bytes.NewBuffer([]byte{})
+	g.SetLogger(slog.New(slog.NewTextHandler(buff, nil)))
+	g.FindPath(1, 5)
+	g.FindPath(6, 1)
+	g.InitSearch()
+	g.FindCycles(1)
+	for _, want := range []string{
+		`msg="Path found" start=1 end=5 path="[1 2 3 4 5]"`,
+		`msg="No path exists" start=6 end=1`,
+		`msg="Cycle found" from=2 to=5 path="[2 3 4 5]"`,
+	} {
+		if !strings.Contains(buff.String(), want) {
+			t.Errorf("Log is missing %v:\n%v", want, buff.String())
+		}
+	}
+
+	u := initGraph(false)
+	buff.Reset()
+	u.SetLogger(slog.New(slog.NewTextHandler(buff, nil)))
+	u.InitSearch()
+	u.FindArticulationVectors(1)
+	if strings.Count(buff.String(), `msg="Articulation vertex found"`) != 3 {
+		t.Errorf("Expected three articulation vertices in the log:\n%v", buff.String())
+	}
+	u.SetLogger(nil)
+	buff.Reset()
+	u.FindPath(1, 5)
+	if buff.Len() != 0 {
+		t.Error("Graph should be silent without a logger")
+	}
 }
 
-func switchToBuffer() *bytes.Buffer {
-	Output = bytes.NewBuffer([]byte{})
-	buff := Output.(*bytes.Buffer)
-	return buff
+func TestVertexNumbering(t *testing.T) {
+	// With contiguous ids the largest id is also the number of distinct
+	// vertices, which is what Read used to count
+	g := initGraph(false)
+	if g.NumVertices() != 10 {
+		t.Errorf("Expected 10 vertices in graph1, got %v", g.NumVertices())
+	}
+
+	// Skipped ids become isolated vertices instead of being left out
+	g = NewGraph(false)
+	if err := g.Load(strings.NewReader("1 5\n")); err != nil {
+		t.Fatal(err)
+	}
+	if g.NumVertices() != 5 {
+		t.Errorf("Expected vertices 1..5, got %v", g.NumVertices())
+	}
+	if components := g.ConnectedComponents(); len(components) != 4 {
+		t.Errorf("Vertices 2, 3 and 4 should be isolated: %v", components)
+	}
+	g.InsertEdge(2, 7, false)
+	g.InsertVertex(3)
+	if g.NumVertices() != 7 || g.NumEdges() != 2 {
+		t.Errorf("InsertEdge should grow the graph to 7 vertices, got %v", g.NumVertices())
+	}
 }
 
-func silenceOutput() {
-	var err error
-	if Output, err = os.Open(os.DevNull); err != nil {
-		panic(err)
+func TestLoad(t *testing.T) {
+	g := NewGraph(false)
+	err := g.Load(strings.NewReader("# roads\n1 2 5\n\n2 3\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.NumEdges() != 2 || g.NumVertices() != 3 {
+		t.Errorf("Incorrect graph size %v, %v", g.NumEdges(), g.NumVertices())
+	}
+	if e, _ := g.Edge(g.EdgeIDs(1, 2)[0]); e.Weight != 5 {
+		t.Errorf("Incorrect weight %v", e.Weight)
+	}
+	for _, bad := range []string{"1\n", "1 x\n", "1 2 3 4\n", "0 2\n"} {
+		if err := NewGraph(false).Load(strings.NewReader(bad)); err == nil {
+			t.Errorf("Expected %q to be rejected", bad)
+		}
 	}
 }
+
+func initGraph(directed bool) *Graph {
+	g := NewGraph(directed)
+	g.Read("./test_data/graph1.txt")
+	return g
+}
@@ -0,0 +1,163 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+)
+
+// HamiltonianPath searches for a path visiting every vertex exactly once,
+// following edge direction in directed graphs. The search backtracks and is
+// exponential in the worst case, so it is meant for small graphs.
+func (g *Graph) HamiltonianPath(ctx context.Context) (*Tour, error) {
+	h := newHamiltonianSearch(ctx, g, false)
+	for start := 1; start <= g.nVertices; start++ {
+		if h.run(start) {
+			return h.tour(), nil
+		}
+		if h.err != nil {
+			return nil, h.err
+		}
+	}
+	return nil, errors.New("No Hamiltonian path exists")
+}
+
+// HamiltonianCycle searches for a cycle visiting every vertex exactly once.
+// Like HamiltonianPath it is meant for small graphs.
+func (g *Graph) HamiltonianCycle(ctx context.Context) (*Tour, error) {
+	h := newHamiltonianSearch(ctx, g, true)
+	if g.nVertices > 0 && h.run(1) {
+		return h.tour(), nil
+	}
+	if h.err != nil {
+		return nil, h.err
+	}
+	return nil, errors.New("No Hamiltonian cycle exists")
+}
+
+// hamiltonianSearch holds the state of the Hamiltonian backtracking search
+type hamiltonianSearch struct {
+	ctx     context.Context
+	g       *Graph
+	closed  bool
+	w       [][]int
+	out     map[int][]int // distinct successors of each vertex
+	in      map[int][]int // distinct predecessors of each vertex
+	visited map[int]bool
+	path    []int
+	err     error
+}
+
+func newHamiltonianSearch(ctx context.Context, g *Graph, closed bool) *hamiltonianSearch {
+	h := &hamiltonianSearch{ctx: ctx, g: g, closed: closed}
+	h.w, _ = g.weightMatrix()
+	h.out = make(map[int][]int)
+	h.in = make(map[int][]int)
+	for x := 1; x <= g.nVertices; x++ {
+		for y := 1; y <= g.nVertices; y++ {
+			if x != y && h.w[x][y] != math.MaxInt {
+				h.out[x] = append(h.out[x], y)
+				h.in[y] = append(h.in[y], x)
+			}
+		}
+	}
+	return h
+}
+
+func (h *hamiltonianSearch) run(start int) bool {
+	h.visited = map[int]bool{start: true}
+	h.path = []int{start}
+	return h.extend()
+}
+
+func (h *hamiltonianSearch) extend() bool {
+	if err := h.ctx.Err(); err != nil {
+		h.err = err
+		return false
+	}
+	current := h.path[len(h.path)-1]
+	if len(h.path) == h.g.nVertices {
+		return !h.closed || h.w[current][h.path[0]] != math.MaxInt
+	}
+	if !h.feasible(current) {
+		return false
+	}
+
+	// Try successors with the fewest onward options first (Warnsdorff's rule)
+	candidates := []int{}
+	for _, y := range h.out[current] {
+		if !h.visited[y] {
+			candidates = append(candidates, y)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return h.onward(candidates[i]) < h.onward(candidates[j])
+	})
+	for _, y := range candidates {
+		h.visited[y] = true
+		h.path = append(h.path, y)
+		if h.extend() {
+			return true
+		}
+		h.path = h.path[:len(h.path)-1]
+		delete(h.visited, y)
+		if h.err != nil {
+			return false
+		}
+	}
+	return false
+}
+
+// onward counts the unvisited successors of v
+func (h *hamiltonianSearch) onward(v int) int {
+	count := 0
+	for _, y := range h.out[v] {
+		if !h.visited[y] {
+			count++
+		}
+	}
+	return count
+}
+
+// feasible prunes partial paths that can no longer be completed: every
+// unvisited vertex must still be reachable from the current endpoint through
+// unvisited vertices, and each must keep an unvisited or endpoint predecessor
+func (h *hamiltonianSearch) feasible(current int) bool {
+	reached := map[int]bool{current: true}
+	stack := []int{current}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, y := range h.out[v] {
+			if !h.visited[y] && !reached[y] {
+				reached[y] = true
+				stack = append(stack, y)
+			}
+		}
+	}
+	for v := 1; v <= h.g.nVertices; v++ {
+		if h.visited[v] {
+			continue
+		}
+		if !reached[v] {
+			return false
+		}
+		hasPredecessor := false
+		for _, x := range h.in[v] {
+			if x == current || !h.visited[x] {
+				hasPredecessor = true
+				break
+			}
+		}
+		if !hasPredecessor {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *hamiltonianSearch) tour() *Tour {
+	order := append([]int{}, h.path...)
+	return &Tour{Vertices: order, Cost: tourCost(h.w, order, h.closed), Closed: h.closed}
+}
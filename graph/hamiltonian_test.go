@@ -0,0 +1,58 @@
+package graph
+
+import (
+	"context"
+	"testing"
+)
+
+func TestHamiltonianPath(t *testing.T) {
+	// A path 1-2-3-4 with a chord 1-3 has a Hamiltonian path but no cycle
+	g := NewGraph(false)
+	g.InsertWeightedEdge(1, 2, 1, false)
+	g.InsertWeightedEdge(2, 3, 2, false)
+	g.InsertWeightedEdge(3, 4, 3, false)
+	g.InsertWeightedEdge(1, 3, 4, false)
+	path, err := g.HamiltonianPath(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTour(t, g, path)
+	if _, err := g.HamiltonianCycle(context.Background()); err == nil {
+		t.Error("Found a Hamiltonian cycle when none exists")
+	}
+
+	// The Read graph has two components so no path can visit everything
+	if _, err := initGraph(true).HamiltonianPath(context.Background()); err == nil {
+		t.Error("Found a Hamiltonian path when none exists")
+	}
+}
+
+func TestHamiltonianCycle(t *testing.T) {
+	g := initCompleteGraph()
+	cycle, err := g.HamiltonianCycle(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTour(t, g, cycle)
+
+	// Directed cycles must follow edge direction
+	d := NewGraph(true)
+	d.InsertEdge(1, 2, true)
+	d.InsertEdge(2, 3, true)
+	d.InsertEdge(1, 3, true)
+	if _, err := d.HamiltonianCycle(context.Background()); err == nil {
+		t.Error("Found a directed Hamiltonian cycle when none exists")
+	}
+	d.InsertEdge(3, 1, true)
+	if _, err := d.HamiltonianCycle(context.Background()); err != nil {
+		t.Error("Did not find the directed Hamiltonian cycle")
+	}
+}
+
+func TestHamiltonianCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := initCompleteGraph().HamiltonianCycle(ctx); err != context.Canceled {
+		t.Error("Search ignored a canceled context")
+	}
+}
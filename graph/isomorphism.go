@@ -0,0 +1,254 @@
+package graph
+
+import "sort"
+
+// IsIsomorphic reports whether the two graphs have the same structure, and if
+// so returns a mapping from the vertices of g to the vertices of other. Edge
+// weights and parallel edges are ignored.
+func (g *Graph) IsIsomorphic(other *Graph) (map[int]int, bool) {
+	if g.Directed != other.Directed || g.nVertices != other.nVertices ||
+		newVF2Adjacency(g).pairs() != newVF2Adjacency(other).pairs() {
+		return nil, false
+	}
+	return other.FindSubgraph(g, true)
+}
+
+// FindSubgraph returns the first occurrence of pattern inside g as a mapping
+// from pattern vertices to vertices of g
+func (g *Graph) FindSubgraph(pattern *Graph, induced bool) (map[int]int, bool) {
+	var found map[int]int
+	g.SubgraphMatches(pattern, induced, func(mapping map[int]int) bool {
+		found = mapping
+		return false
+	})
+	return found, found != nil
+}
+
+// SubgraphMatches streams every occurrence of pattern inside g to the match
+// callback, which returns false to stop the search. Each mapping sends pattern
+// vertices to distinct vertices of g so that every pattern edge is an edge of
+// g; induced matching additionally requires that non-adjacent pattern vertices
+// map to non-adjacent vertices. The search follows VF2, extending a partial
+// mapping one pattern vertex at a time and pruning with adjacency and degree
+// look-ahead checks.
+func (g *Graph) SubgraphMatches(pattern *Graph, induced bool, match func(map[int]int) bool) {
+	if g.Directed != pattern.Directed || pattern.nVertices > g.nVertices {
+		return
+	}
+	s := &vf2State{
+		p:       newVF2Adjacency(pattern),
+		t:       newVF2Adjacency(g),
+		induced: induced,
+		core:    make(map[int]int),
+		reverse: make(map[int]int),
+		match:   match,
+	}
+	s.order = s.p.matchingOrder()
+	s.search(0)
+}
+
+// vf2Adjacency is a simple-graph view of a Graph with successor and
+// predecessor sets (identical for undirected graphs)
+type vf2Adjacency struct {
+	n   int
+	out map[int]map[int]bool
+	in  map[int]map[int]bool
+}
+
+func newVF2Adjacency(g *Graph) *vf2Adjacency {
+	a := &vf2Adjacency{n: g.nVertices, out: make(map[int]map[int]bool), in: make(map[int]map[int]bool)}
+	for v := 1; v <= g.nVertices; v++ {
+		a.out[v] = make(map[int]bool)
+		a.in[v] = make(map[int]bool)
+	}
+	for x, edgeNode := range g.Edges {
+		for ; edgeNode != nil; edgeNode = edgeNode.Next {
+			a.out[x][edgeNode.Y] = true
+			a.in[edgeNode.Y][x] = true
+		}
+	}
+	return a
+}
+
+// pairs counts the distinct adjacent ordered pairs, so each undirected edge
+// counts twice and parallel edges count once
+func (a *vf2Adjacency) pairs() int {
+	total := 0
+	for _, succ := range a.out {
+		total += len(succ)
+	}
+	return total
+}
+
+// matchingOrder visits pattern vertices so that each one after the first of
+// its component is adjacent to an earlier one, starting from high degrees
+// (the VF2++ ordering heuristic)
+func (a *vf2Adjacency) matchingOrder() []int {
+	degree := func(v int) int { return len(a.out[v]) + len(a.in[v]) }
+	vertices := make([]int, 0, a.n)
+	for v := 1; v <= a.n; v++ {
+		vertices = append(vertices, v)
+	}
+	sort.SliceStable(vertices, func(i, j int) bool { return degree(vertices[i]) > degree(vertices[j]) })
+
+	placed := make(map[int]bool)
+	order := []int{}
+	for _, root := range vertices {
+		if placed[root] {
+			continue
+		}
+		placed[root] = true
+		queue := []int{root}
+		for head := 0; head < len(queue); head++ {
+			v := queue[head]
+			order = append(order, v)
+			next := []int{}
+			for y := range a.out[v] {
+				next = append(next, y)
+			}
+			for y := range a.in[v] {
+				next = append(next, y)
+			}
+			sort.SliceStable(next, func(i, j int) bool {
+				if degree(next[i]) != degree(next[j]) {
+					return degree(next[i]) > degree(next[j])
+				}
+				return next[i] < next[j]
+			})
+			for _, y := range next {
+				if !placed[y] {
+					placed[y] = true
+					queue = append(queue, y)
+				}
+			}
+		}
+	}
+	return order
+}
+
+// vf2State is a partial mapping from pattern vertices to target vertices
+type vf2State struct {
+	p, t    *vf2Adjacency
+	induced bool
+	order   []int
+	core    map[int]int // pattern vertex -> target vertex
+	reverse map[int]int // target vertex -> pattern vertex
+	match   func(map[int]int) bool
+}
+
+// search extends the mapping with order[depth], returning false once the
+// callback asks to stop
+func (s *vf2State) search(depth int) bool {
+	if depth == len(s.order) {
+		mapping := make(map[int]int, len(s.core))
+		for u, v := range s.core {
+			mapping[u] = v
+		}
+		return s.match(mapping)
+	}
+	u := s.order[depth]
+	for _, v := range s.candidates(u) {
+		if !s.feasible(u, v) {
+			continue
+		}
+		s.core[u] = v
+		s.reverse[v] = u
+		keepGoing := s.search(depth + 1)
+		delete(s.core, u)
+		delete(s.reverse, v)
+		if !keepGoing {
+			return false
+		}
+	}
+	return true
+}
+
+// candidates narrows the target vertices for u to the neighbours of the image
+// of an already mapped neighbour of u, when there is one
+func (s *vf2State) candidates(u int) []int {
+	pool := []int{}
+	for y := range s.p.out[u] {
+		if v, ok := s.core[y]; ok {
+			for x := range s.t.in[v] {
+				pool = append(pool, x)
+			}
+			sort.Ints(pool)
+			return pool
+		}
+	}
+	for y := range s.p.in[u] {
+		if v, ok := s.core[y]; ok {
+			for x := range s.t.out[v] {
+				pool = append(pool, x)
+			}
+			sort.Ints(pool)
+			return pool
+		}
+	}
+	for v := 1; v <= s.t.n; v++ {
+		pool = append(pool, v)
+	}
+	return pool
+}
+
+// feasible checks whether u can be mapped to v given the current mapping
+func (s *vf2State) feasible(u, v int) bool {
+	if _, used := s.reverse[v]; used {
+		return false
+	}
+	if len(s.p.out[u]) > len(s.t.out[v]) || len(s.p.in[u]) > len(s.t.in[v]) {
+		return false
+	}
+	if s.p.out[u][u] != s.t.out[v][v] && (s.induced || s.p.out[u][u]) {
+		return false // self-loops must match
+	}
+
+	// Every pattern edge between u and mapped vertices must exist in the target
+	unmappedOut, unmappedIn := 0, 0
+	for y := range s.p.out[u] {
+		if w, ok := s.core[y]; ok {
+			if !s.t.out[v][w] {
+				return false
+			}
+		} else if y != u {
+			unmappedOut++
+		}
+	}
+	for y := range s.p.in[u] {
+		if w, ok := s.core[y]; ok {
+			if !s.t.in[v][w] {
+				return false
+			}
+		} else if y != u {
+			unmappedIn++
+		}
+	}
+
+	// Induced matches may not add edges between mapped vertices
+	if s.induced {
+		for w := range s.t.out[v] {
+			if y, ok := s.reverse[w]; ok && !s.p.out[u][y] {
+				return false
+			}
+		}
+		for w := range s.t.in[v] {
+			if y, ok := s.reverse[w]; ok && !s.p.in[u][y] {
+				return false
+			}
+		}
+	}
+
+	// Look ahead: v needs at least as many free neighbours as u still has to place
+	freeOut, freeIn := 0, 0
+	for w := range s.t.out[v] {
+		if _, ok := s.reverse[w]; !ok && w != v {
+			freeOut++
+		}
+	}
+	for w := range s.t.in[v] {
+		if _, ok := s.reverse[w]; !ok && w != v {
+			freeIn++
+		}
+	}
+	return unmappedOut <= freeOut && unmappedIn <= freeIn
+}
@@ -0,0 +1,72 @@
+package graph
+
+import "testing"
+
+func TestIsIsomorphic(t *testing.T) {
+	g := CycleGraph(6)
+	relabeled := NewGraph(false)
+	order := []int{4, 1, 6, 2, 5, 3}
+	for i := range order {
+		relabeled.InsertEdge(order[i], order[(i+1)%6], false)
+	}
+	mapping, ok := g.IsIsomorphic(relabeled)
+	if !ok {
+		t.Fatal("Did not detect isomorphic cycles")
+	}
+	for _, e := range g.EdgeList() {
+		if !newVF2Adjacency(relabeled).out[mapping[e.X]][mapping[e.Y]] {
+			t.Error("Mapping does not preserve edges")
+		}
+	}
+
+	if _, ok := PathGraph(4).IsIsomorphic(StarGraph(4)); ok {
+		t.Error("A path and a star are not isomorphic")
+	}
+	if _, ok := initGraph(true).IsIsomorphic(initGraph(true).Transpose()); ok {
+		t.Error("Graph1 is not isomorphic to its transpose")
+	}
+
+	doubled := NewGraph(false)
+	for _, e := range [][2]int{{1, 2}, {2, 3}, {3, 1}, {1, 2}} {
+		doubled.InsertEdge(e[0], e[1], false)
+	}
+	if _, ok := doubled.IsIsomorphic(CycleGraph(3)); !ok {
+		t.Error("A triangle with a doubled edge should match a plain triangle")
+	}
+	if _, ok := CycleGraph(3).IsIsomorphic(doubled); !ok {
+		t.Error("A plain triangle should match a triangle with a doubled edge")
+	}
+}
+
+func TestSubgraphMatches(t *testing.T) {
+	countMatches := func(g, pattern *Graph, induced bool) int {
+		count := 0
+		g.SubgraphMatches(pattern, induced, func(map[int]int) bool {
+			count++
+			return true
+		})
+		return count
+	}
+	k4 := CompleteGraph(4)
+	if countMatches(k4, CycleGraph(3), false) != 24 {
+		t.Error("Incorrect number of triangles found in K4")
+	}
+	if countMatches(k4, PathGraph(3), true) != 0 {
+		t.Error("K4 has no induced paths of length two")
+	}
+	if countMatches(k4, PathGraph(3), false) != 24 {
+		t.Error("Incorrect number of non-induced paths found in K4")
+	}
+
+	edge := NewGraph(true)
+	edge.InsertEdge(1, 2, true)
+	if countMatches(initGraph(true), edge, true) != 9 {
+		t.Error("Every directed edge should match a single edge pattern")
+	}
+
+	square := CycleGraph(4)
+	mapping, ok := initGraph(false).FindSubgraph(square, true)
+	if !ok || mapping[1] < 2 || mapping[1] > 5 {
+		t.Error("Did not find the 4-cycle in graph1")
+	}
+}
@@ -0,0 +1,188 @@
+package graph
+
+import "iter"
+
+// Enum of traversal events (i.e ENTER_VERTEX, EXIT_VERTEX, VISIT_EDGE)
+type VisitEvent int
+
+const (
+	ENTER_VERTEX = 1 + iota
+	EXIT_VERTEX
+	VISIT_EDGE
+)
+
+// A Visit is a single step of a BFS or DFS iterator
+type Visit struct {
+	Event  VisitEvent
+	Vertex int      // Vertex entered or exited, or the vertex the edge leaves
+	Edge   Edge     // Edge followed, for VISIT_EDGE events
+	Type   EdgeType // Classification of the edge, for VISIT_EDGE events
+	Depth  int      // Depth of Vertex in the search tree
+}
+
+// BFS returns an iterator over a breadth-first search from start. Each vertex
+// is entered when it leaves the queue, then each of its edges is visited, then
+// it is exited. Edges that discover a vertex are TREE edges; others are BACK
+// edges when they lead to the vertex itself or one of its ancestors and CROSS
+// edges otherwise. Undirected edges are visited once.
+//
+// The search keeps its own state, so it can run alongside other traversals of
+// the graph, and stops as soon as the loop body breaks.
+func (g *Graph) BFS(start int) iter.Seq[Visit] {
+	return func(yield func(Visit) bool) {
+		n := g.nVertices
+		if start < 1 || start > n {
+			return
+		}
+		depth := make([]int, n+1)
+		parent := make([]int, n+1)
+		exited := make([]bool, n+1)
+		for i := range depth {
+			depth[i] = -1
+			parent[i] = -1
+		}
+		loopSeen := make(map[int]bool) // Undirected self-loops appear twice
+		depth[start] = 0
+		queue := []int{start}
+		for head := 0; head < len(queue); head++ {
+			x := queue[head]
+			if !yield(Visit{Event: ENTER_VERTEX, Vertex: x, Depth: depth[x]}) {
+				return
+			}
+			for edgeNode := g.Edges[x]; edgeNode != nil; edgeNode = edgeNode.Next {
+				y := edgeNode.Y
+				if !g.Directed && (exited[y] || x == y && loopSeen[edgeNode.ID]) {
+					continue // Already visited from the other end
+				}
+				if x == y {
+					loopSeen[edgeNode.ID] = true
+				}
+				edgeType := EdgeType(CROSS)
+				if depth[y] == -1 {
+					depth[y] = depth[x] + 1
+					parent[y] = x
+					queue = append(queue, y)
+					edgeType = TREE
+				} else if depth[y] <= depth[x] && isTreeAncestor(parent, y, x) {
+					edgeType = BACK
+				}
+				if !yield(edgeVisit(x, edgeNode, edgeType, depth[x])) {
+					return
+				}
+			}
+			exited[x] = true
+			if !yield(Visit{Event: EXIT_VERTEX, Vertex: x, Depth: depth[x]}) {
+				return
+			}
+		}
+	}
+}
+
+// DFS returns an iterator over a depth-first search from start. Each vertex is
+// entered when discovered and exited once all of its edges are visited. Edges
+// are classified as TREE, BACK, FORWARD or CROSS; undirected graphs have only
+// TREE and BACK edges, and each undirected edge is visited once.
+//
+// The search keeps its own explicit stack and state, so it handles deep graphs
+// without recursion, can run alongside other traversals of the graph, and
+// stops as soon as the loop body breaks.
+func (g *Graph) DFS(start int) iter.Seq[Visit] {
+	return func(yield func(Visit) bool) {
+		n := g.nVertices
+		if start < 1 || start > n {
+			return
+		}
+		type frame struct {
+			v        int
+			next     *EdgeNode // Next adjacency list entry to visit
+			treeEdge int       // Id of the edge that discovered v
+		}
+		state := make([]VerticeState, n+1)
+		entry := make([]int, n+1)
+		for i := range state {
+			state[i] = UNDISCOVERED
+		}
+		loopSeen := make(map[int]bool)
+		time := 1
+		state[start] = DISCOVERED
+		entry[start] = time
+		if !yield(Visit{Event: ENTER_VERTEX, Vertex: start}) {
+			return
+		}
+		stack := []frame{{v: start, next: g.Edges[start]}}
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			x, depth := top.v, len(stack)-1
+			edgeNode := top.next
+			if edgeNode == nil {
+				stack = stack[:len(stack)-1]
+				state[x] = PROCESSED
+				if !yield(Visit{Event: EXIT_VERTEX, Vertex: x, Depth: depth}) {
+					return
+				}
+				continue
+			}
+			top.next = edgeNode.Next
+			y := edgeNode.Y
+
+			var edgeType EdgeType
+			switch {
+			case state[y] == UNDISCOVERED:
+				edgeType = TREE
+			case !g.Directed && (edgeNode.ID == top.treeEdge || state[y] == PROCESSED):
+				continue // Already visited from the other end
+			case !g.Directed && x == y && loopSeen[edgeNode.ID]:
+				continue
+			case state[y] == DISCOVERED:
+				edgeType = BACK
+				if x == y {
+					loopSeen[edgeNode.ID] = true
+				}
+			case entry[y] > entry[x]:
+				edgeType = FORWARD
+			default:
+				edgeType = CROSS
+			}
+			if !yield(edgeVisit(x, edgeNode, edgeType, depth)) {
+				return
+			}
+			if edgeType == TREE {
+				time++
+				state[y] = DISCOVERED
+				entry[y] = time
+				if !yield(Visit{Event: ENTER_VERTEX, Vertex: y, Depth: depth + 1}) {
+					return
+				}
+				stack = append(stack, frame{v: y, next: g.Edges[y], treeEdge: edgeNode.ID})
+			}
+		}
+	}
+}
+
+// edgeVisit describes following an adjacency list entry of x
+func edgeVisit(x int, edgeNode *EdgeNode, edgeType EdgeType, depth int) Visit {
+	e := Edge{ID: edgeNode.ID, X: x, Y: edgeNode.Y, Weight: edgeNode.Weight, Attributes: edgeNode.Attributes}
+	return Visit{Event: VISIT_EDGE, Vertex: x, Edge: e, Type: edgeType, Depth: depth}
+}
+
+// isTreeAncestor reports whether a is v or one of its ancestors in the tree
+// described by parent
+func isTreeAncestor(parent []int, a, v int) bool {
+	for ; v != -1; v = parent[v] {
+		if v == a {
+			return true
+		}
+	}
+	return false
+}
+
+var visitEvents = [...]string{
+	"ENTER_VERTEX",
+	"EXIT_VERTEX",
+	"VISIT_EDGE",
+}
+
+// String for the VisitEvent enables this enum to appear as a string when passed to fmt
+func (event VisitEvent) String() string {
+	return visitEvents[event-1]
+}
@@ -0,0 +1,176 @@
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// legacyVisits renders visits the way BreadthFirstSearch and DepthFirstSearch do
+func legacyVisits(visits func(func(Visit) bool)) [][]int {
+	out := [][]int{}
+	for v := range visits {
+		switch v.Event {
+		case ENTER_VERTEX:
+			out = append(out, []int{v.Vertex})
+		case VISIT_EDGE:
+			out = append(out, []int{v.Edge.X, v.Edge.Y})
+		}
+	}
+	return out
+}
+
+func TestIteratorsMatchTraversals(t *testing.T) {
+	g := initGraph(true)
+	g.InitSearch()
+	if want, got := g.BreadthFirstSearch(1), legacyVisits(g.BFS(1)); !reflect.DeepEqual(want, got) {
+		t.Errorf("BFS iterator visited %v, expected %v", got, want)
+	}
+	g.InitSearch()
+	if want, got := g.DepthFirstSearch(1), legacyVisits(g.DFS(1)); !reflect.DeepEqual(want, got) {
+		t.Errorf("DFS iterator visited %v, expected %v", got, want)
+	}
+}
+
+func TestDFSEvents(t *testing.T) {
+	g := NewGraph(true)
+	g.InsertEdge(2, 3, true)
+	g.InsertEdge(1, 3, true)
+	g.InsertEdge(1, 2, true)
+	g.InsertEdge(3, 1, true)
+	g.InsertEdge(4, 3, true)
+	got := []string{}
+	for v := range g.DFS(1) {
+		switch v.Event {
+		case VISIT_EDGE:
+			got = append(got, v.Type.String())
+		default:
+			got = append(got, v.Event.String())
+		}
+	}
+	want := []string{"ENTER_VERTEX", "TREE", "ENTER_VERTEX", "TREE", "ENTER_VERTEX", "BACK", "EXIT_VERTEX", "EXIT_VERTEX", "FORWARD", "EXIT_VERTEX"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Incorrect DFS events %v", got)
+	}
+
+	// Classifications agree with the recursive search
+	g = initGraph(true)
+	g.InsertEdge(6, 2, true)
+	g.InsertEdge(5, 5, true)
+	g.InitSearch()
+	g.dfs(1, new(Traversal))
+	for v := range g.DFS(1) {
+		if v.Event == VISIT_EDGE && v.Type != edgeClassificationAfter(g, v.Edge.X, v.Edge.Y) {
+			t.Errorf("Edge %v -> %v classified as %v", v.Edge.X, v.Edge.Y, v.Type)
+		}
+	}
+}
+
+// edgeClassificationAfter classifies an edge once a full DFS of g has finished
+func edgeClassificationAfter(g *Graph, x, y int) EdgeType {
+	switch {
+	case g.Parent[y] == x:
+		return TREE
+	case g.EntryTime[y] <= g.EntryTime[x] && g.ExitTime[y] >= g.ExitTime[x]:
+		return BACK
+	case g.EntryTime[y] > g.EntryTime[x]:
+		return FORWARD
+	}
+	return CROSS
+}
+
+func TestUndirectedIterators(t *testing.T) {
+	g := NewGraph(false)
+	g.AddEdge(1, 2, 0)
+	g.AddEdge(2, 3, 0)
+	g.AddEdge(3, 1, 0)
+	g.AddEdge(3, 4, 0)
+	g.AddEdge(4, 3, 0) // parallel edge
+	g.AddEdge(4, 4, 0) // self-loop
+	for name, visits := range map[string]func(func(Visit) bool){"BFS": g.BFS(1), "DFS": g.DFS(1)} {
+		edges, counts := make(map[int]int), make(map[EdgeType]int)
+		enters, exits := 0, 0
+		for v := range visits {
+			switch v.Event {
+			case ENTER_VERTEX:
+				enters++
+			case EXIT_VERTEX:
+				exits++
+			case VISIT_EDGE:
+				edges[v.Edge.ID]++
+				counts[v.Type]++
+			}
+		}
+		if enters != 4 || exits != 4 || len(edges) != 6 {
+			t.Errorf("%v entered %v, exited %v and visited %v edges", name, enters, exits, len(edges))
+		}
+		for id, c := range edges {
+			if c != 1 {
+				t.Errorf("%v visited edge %v %v times", name, id, c)
+			}
+		}
+		if counts[TREE] != 3 {
+			t.Errorf("%v found %v tree edges", name, counts[TREE])
+		}
+		if name == "DFS" && counts[BACK] != 3 {
+			t.Errorf("DFS should find 3 back edges, found %v", counts)
+		}
+	}
+}
+
+func TestIteratorsBreakEarly(t *testing.T) {
+	g := PathGraph(100000)
+	count := 0
+	for v := range g.DFS(1) {
+		if v.Event == ENTER_VERTEX && v.Vertex == 50 {
+			if v.Depth != 49 {
+				t.Errorf("Vertex 50 should be at depth 49, got %v", v.Depth)
+			}
+			break
+		}
+		count++
+	}
+	if count != 1+49+48 { // Enter 1, then a tree edge and an enter per vertex
+		t.Errorf("DFS did not stop at the break: %v visits", count)
+	}
+
+	// Nested iterators do not share state
+	pairs := 0
+	for a := range g.InducedSubgraph([]int{1, 2, 3}).BFS(1) {
+		for b := range g.BFS(1) {
+			if b.Depth > 1 {
+				break
+			}
+			pairs++
+		}
+		if a.Event == EXIT_VERTEX {
+			break
+		}
+	}
+	if pairs != 3*6 { // Three outer visits up to the first exit, six inner visits each
+		t.Errorf("Nested BFS produced %v visits", pairs)
+	}
+}
+
+// benchmarkGraph is a small random graph for timing the traversal iterators
+func benchmarkGraph() *Graph {
+	return ErdosRenyi(2000, 0.005, rand.NewSource(1))
+}
+
+func BenchmarkBreadthFirstSearch(b *testing.B) {
+	g := benchmarkGraph()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.InitSearch()
+		g.BreadthFirstSearch(1)
+	}
+}
+
+func BenchmarkBFSIterator(b *testing.B) {
+	g := benchmarkGraph()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for range g.BFS(1) {
+		}
+	}
+}
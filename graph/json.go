@@ -0,0 +1,73 @@
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// jsonGraph is the serialized form of a Graph
+type jsonGraph struct {
+	Directed         bool                  `json:"directed"`
+	Simple           bool                  `json:"simple,omitempty"`
+	Vertices         int                   `json:"vertices"`
+	VertexAttributes map[string]Attributes `json:"vertexAttributes,omitempty"`
+	Edges            []Edge                `json:"edges"`
+}
+
+// MarshalJSON encodes the vertices, edges, edge ids and attributes of the
+// graph. Edges are listed as in EdgeList; traversal state is not included.
+func (g *Graph) MarshalJSON() ([]byte, error) {
+	j := jsonGraph{Directed: g.Directed, Simple: g.simple, Vertices: g.nVertices, Edges: g.EdgeList()}
+	for v, a := range g.vertexAttributes {
+		if len(a) == 0 {
+			continue
+		}
+		if j.VertexAttributes == nil {
+			j.VertexAttributes = make(map[string]Attributes)
+		}
+		j.VertexAttributes[strconv.Itoa(v)] = a
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON replaces the graph with one decoded by MarshalJSON,
+// inserting the edges in the order they are listed. A simple graph that lists
+// a self-loop or the same edge twice is an error.
+func (g *Graph) UnmarshalJSON(data []byte) error {
+	var j jsonGraph
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	seen := make(map[[2]int]bool)
+	for _, e := range j.Edges {
+		if e.X < 1 || e.X > j.Vertices || e.Y < 1 || e.Y > j.Vertices {
+			return errors.New("Edge endpoint is not a vertex of the graph")
+		}
+		if !j.Simple {
+			continue
+		}
+		if e.X == e.Y {
+			return errors.New("Simple graphs cannot have self-loops")
+		}
+		pair := [2]int{e.X, e.Y}
+		if !j.Directed && e.X > e.Y {
+			pair = [2]int{e.Y, e.X}
+		}
+		if seen[pair] {
+			return errors.New("Edge already exists")
+		}
+		seen[pair] = true
+	}
+	h := fromEdges(j.Directed, j.Vertices, j.Edges)
+	h.simple = j.Simple
+	for key, a := range j.VertexAttributes {
+		v, err := strconv.Atoi(key)
+		if err != nil || v < 1 || v > j.Vertices {
+			return errors.New("Vertex attributes refer to an unknown vertex")
+		}
+		h.vertexAttributes[v] = a
+	}
+	*g = *h
+	return nil
+}
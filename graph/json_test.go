@@ -0,0 +1,79 @@
+package graph
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	for _, directed := range []bool{false, true} {
+		g := labelledGraph()
+		if directed {
+			g = initGraph(true)
+			g.SetVertexAttribute(2, "name", "b")
+			g.SetEdgeAttribute(5, 2, "color", "red")
+			g.InsertVertex(14)
+		}
+		data, err := json.Marshal(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		h := NewGraph(!directed)
+		if err := json.Unmarshal(data, h); err != nil {
+			t.Fatal(err)
+		}
+		if h.Directed != directed || h.NumVertices() != g.NumVertices() || h.NumEdges() != g.NumEdges() {
+			t.Errorf("Round trip changed the graph: %s", data)
+		}
+		if !reflect.DeepEqual(h.EdgeList(), g.EdgeList()) {
+			t.Errorf("Round trip changed the edges: %v", h.EdgeList())
+		}
+		if name, _ := h.VertexAttribute(2, "name"); name != "b" {
+			t.Error("Round trip lost a vertex attribute")
+		}
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	g := NewGraph(true)
+	g.InsertWeightedEdge(1, 2, 3, true)
+	g.SetEdgeAttribute(1, 2, "label", "x")
+	g.SetVertexAttribute(3, "label", "sink")
+	data, _ := json.Marshal(g)
+	want := `{"directed":true,"vertices":3,"vertexAttributes":{"3":{"label":"sink"}},"edges":[{"id":1,"x":1,"y":2,"weight":3,"attributes":{"label":"x"}}]}`
+	if string(data) != want {
+		t.Errorf("Unexpected encoding %s", data)
+	}
+	for _, bad := range []string{`{"vertices":2,"edges":[{"x":1,"y":3}]}`, `{"vertices":2,"vertexAttributes":{"x":{}},"edges":[]}`, `[1]`} {
+		if err := json.Unmarshal([]byte(bad), NewGraph(false)); err == nil {
+			t.Errorf("Decoded invalid graph %s", bad)
+		}
+	}
+}
+
+func TestJSONSimpleGraph(t *testing.T) {
+	g := NewSimpleGraph(false)
+	g.InsertEdge(1, 2, false)
+	g.InsertEdge(2, 3, false)
+	data, _ := json.Marshal(g)
+	h := NewGraph(false)
+	if err := json.Unmarshal(data, h); err != nil || !h.Simple() || h.NumEdges() != 2 {
+		t.Errorf("Simple graph did not survive a round trip: %s", data)
+	}
+
+	for _, bad := range []string{
+		`{"simple":true,"vertices":2,"edges":[{"x":1,"y":2},{"x":2,"y":1}]}`,
+		`{"simple":true,"vertices":2,"edges":[{"x":1,"y":2},{"x":1,"y":2}]}`,
+		`{"simple":true,"vertices":2,"edges":[{"x":2,"y":2}]}`,
+	} {
+		if err := json.Unmarshal([]byte(bad), NewGraph(false)); err == nil {
+			t.Errorf("Decoded invalid simple graph %s", bad)
+		}
+	}
+	// Opposite directions are distinct edges of a directed simple graph
+	d := `{"directed":true,"simple":true,"vertices":2,"edges":[{"x":1,"y":2},{"x":2,"y":1}]}`
+	if err := json.Unmarshal([]byte(d), NewGraph(false)); err != nil {
+		t.Errorf("Rejected a directed simple graph: %v", err)
+	}
+}
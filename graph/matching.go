@@ -0,0 +1,594 @@
+package graph
+
+// minWeightPerfectMatching pairs up an even number of vertices so that the sum
+// of weight(a, b) over all pairs is minimal. Every pair must have a weight.
+//
+// The pairs come from a maximum weight matching of maximum cardinality on the
+// complete graph with weights max - weight(a, b): every perfect matching has
+// the same number of pairs, so the heaviest one under the flipped weights is
+// the lightest under the original ones.
+func minWeightPerfectMatching(vertices []int, weight func(a, b int) int) [][2]int {
+	n := len(vertices)
+	heaviest := 0
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if w := weight(vertices[i], vertices[j]); w > heaviest {
+				heaviest = w
+			}
+		}
+	}
+	edges := [][3]int{}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			edges = append(edges, [3]int{i, j, heaviest - weight(vertices[i], vertices[j])})
+		}
+	}
+	mate := maxWeightMatching(n, edges, true)
+	pairs := [][2]int{}
+	for i, j := range mate {
+		if i < j {
+			pairs = append(pairs, [2]int{vertices[i], vertices[j]})
+		}
+	}
+	return pairs
+}
+
+// maxWeightMatching computes a maximum weight matching of the graph on the
+// vertices 0..n-1 with the given (i, j, weight) edges, or the heaviest of the
+// largest matchings if maxCardinality is set. It returns the vertex each
+// vertex is matched to, or -1 for unmatched vertices.
+//
+// This is Edmonds' blossom algorithm with the primal-dual method of Galil,
+// running in O(n^3) time. Vertices and blossoms are labelled S (1) or T (2)
+// while alternating trees grow from the free vertices; odd cycles of S
+// vertices shrink into blossoms, and when no tight edge is left the dual
+// variables change by the largest step that keeps every edge slack
+// non-negative. All computations stay in integers.
+func maxWeightMatching(n int, edges [][3]int, maxCardinality bool) []int {
+	m := &blossomMatching{n: n, edges: edges}
+	return m.solve(maxCardinality)
+}
+
+// blossomMatching is the state of maxWeightMatching. Edge k has the endpoints
+// 2k and 2k+1, so endpoint p belongs to edge p/2 and p^1 is the opposite end.
+// Indices 0..n-1 are vertices and n..2n-1 are blossoms that are not trivial.
+type blossomMatching struct {
+	n         int
+	edges     [][3]int
+	endpoint  []int   // vertex at each endpoint
+	neighbend [][]int // remote endpoints of the edges at each vertex
+	mate      []int   // endpoint each vertex is matched through, or -1
+
+	label     []int // 0 for free, 1 for S, 2 for T, with bit 4 marking scans
+	labelend  []int // endpoint through which a vertex or blossom got its label
+	inblossom []int // top level blossom containing each vertex
+
+	blossomparent    []int
+	blossomchilds    [][]int
+	blossombase      []int
+	blossomendps     [][]int // endpoints joining consecutive children
+	bestedge         []int   // least slack edge to a different S blossom
+	blossombestedges [][]int
+	unusedblossoms   []int
+	dualvar          []int
+	allowedge        []bool // edges with zero slack
+	queue            []int  // S vertices whose edges are yet to be scanned
+}
+
+func (m *blossomMatching) slack(k int) int {
+	e := m.edges[k]
+	return m.dualvar[e[0]] + m.dualvar[e[1]] - 2*e[2]
+}
+
+// blossomLeaves returns the vertices inside blossom b
+func (m *blossomMatching) blossomLeaves(b int) []int {
+	if b < m.n {
+		return []int{b}
+	}
+	leaves := []int{}
+	for _, t := range m.blossomchilds[b] {
+		leaves = append(leaves, m.blossomLeaves(t)...)
+	}
+	return leaves
+}
+
+// assignLabel labels vertex w and its top level blossom with t, reached
+// through endpoint p. A T blossom passes the S label on to its mate.
+func (m *blossomMatching) assignLabel(w, t, p int) {
+	b := m.inblossom[w]
+	m.label[w], m.label[b] = t, t
+	m.labelend[w], m.labelend[b] = p, p
+	m.bestedge[w], m.bestedge[b] = -1, -1
+	if t == 1 {
+		m.queue = append(m.queue, m.blossomLeaves(b)...)
+	} else if t == 2 {
+		base := m.blossombase[b]
+		m.assignLabel(m.endpoint[m.mate[base]], 1, m.mate[base]^1)
+	}
+}
+
+// scanBlossom traces back from v and w towards the roots of their trees and
+// returns the base of the new blossom where the paths meet, or -1 when they
+// reach different roots and so form an augmenting path
+func (m *blossomMatching) scanBlossom(v, w int) int {
+	path := []int{}
+	base := -1
+	for v != -1 || w != -1 {
+		b := m.inblossom[v]
+		if m.label[b]&4 != 0 {
+			base = m.blossombase[b]
+			break
+		}
+		path = append(path, b)
+		m.label[b] = 5
+		if m.labelend[b] == -1 {
+			v = -1
+		} else {
+			v = m.endpoint[m.labelend[b]]
+			b = m.inblossom[v]
+			v = m.endpoint[m.labelend[b]]
+		}
+		if w != -1 {
+			v, w = w, v
+		}
+	}
+	for _, b := range path {
+		m.label[b] = 1
+	}
+	return base
+}
+
+// addBlossom shrinks the cycle closed by edge k, which joins two S vertices
+// of the same tree, into a new blossom with the given base
+func (m *blossomMatching) addBlossom(base, k int) {
+	v, w := m.edges[k][0], m.edges[k][1]
+	bb, bv, bw := m.inblossom[base], m.inblossom[v], m.inblossom[w]
+	b := m.unusedblossoms[len(m.unusedblossoms)-1]
+	m.unusedblossoms = m.unusedblossoms[:len(m.unusedblossoms)-1]
+	m.blossombase[b] = base
+	m.blossomparent[b] = -1
+	m.blossomparent[bb] = b
+
+	path, endps := []int{}, []int{}
+	for bv != bb {
+		m.blossomparent[bv] = b
+		path = append(path, bv)
+		endps = append(endps, m.labelend[bv])
+		v = m.endpoint[m.labelend[bv]]
+		bv = m.inblossom[v]
+	}
+	path = append(path, bb)
+	reverseInts(path)
+	reverseInts(endps)
+	endps = append(endps, 2*k)
+	for bw != bb {
+		m.blossomparent[bw] = b
+		path = append(path, bw)
+		endps = append(endps, m.labelend[bw]^1)
+		w = m.endpoint[m.labelend[bw]]
+		bw = m.inblossom[w]
+	}
+	m.blossomchilds[b] = path
+	m.blossomendps[b] = endps
+
+	m.label[b] = 1
+	m.labelend[b] = m.labelend[bb]
+	m.dualvar[b] = 0
+	for _, leaf := range m.blossomLeaves(b) {
+		if m.label[m.inblossom[leaf]] == 2 {
+			// T vertices inside the blossom become S vertices
+			m.queue = append(m.queue, leaf)
+		}
+		m.inblossom[leaf] = b
+	}
+
+	// Keep the least slack edge from the new blossom to each other S blossom
+	bestedgeto := make([]int, 2*m.n)
+	for i := range bestedgeto {
+		bestedgeto[i] = -1
+	}
+	for _, child := range path {
+		var lists [][]int
+		if m.blossombestedges[child] == nil {
+			for _, leaf := range m.blossomLeaves(child) {
+				list := []int{}
+				for _, p := range m.neighbend[leaf] {
+					list = append(list, p/2)
+				}
+				lists = append(lists, list)
+			}
+		} else {
+			lists = [][]int{m.blossombestedges[child]}
+		}
+		for _, list := range lists {
+			for _, e := range list {
+				j := m.edges[e][1]
+				if m.inblossom[j] == b {
+					j = m.edges[e][0]
+				}
+				bj := m.inblossom[j]
+				if bj != b && m.label[bj] == 1 && (bestedgeto[bj] == -1 || m.slack(e) < m.slack(bestedgeto[bj])) {
+					bestedgeto[bj] = e
+				}
+			}
+		}
+		m.blossombestedges[child] = nil
+		m.bestedge[child] = -1
+	}
+	best := []int{}
+	for _, e := range bestedgeto {
+		if e != -1 {
+			best = append(best, e)
+		}
+	}
+	m.blossombestedges[b] = best
+	m.bestedge[b] = -1
+	for _, e := range best {
+		if m.bestedge[b] == -1 || m.slack(e) < m.slack(m.bestedge[b]) {
+			m.bestedge[b] = e
+		}
+	}
+}
+
+// expandBlossom turns the children of blossom b back into top level
+// blossoms. Mid-stage, the children of a T blossom on the path through it
+// are relabelled so the alternating tree stays intact.
+func (m *blossomMatching) expandBlossom(b int, endstage bool) {
+	for _, s := range m.blossomchilds[b] {
+		m.blossomparent[s] = -1
+		if s < m.n {
+			m.inblossom[s] = s
+		} else if endstage && m.dualvar[s] == 0 {
+			m.expandBlossom(s, endstage)
+		} else {
+			for _, leaf := range m.blossomLeaves(s) {
+				m.inblossom[leaf] = s
+			}
+		}
+	}
+	if !endstage && m.label[b] == 2 {
+		childs, endps := m.blossomchilds[b], m.blossomendps[b]
+		at := func(j int) int { return (j + len(childs)) % len(childs) }
+		entrychild := m.inblossom[m.endpoint[m.labelend[b]^1]]
+		j := indexOf(childs, entrychild)
+		jstep, endptrick := -1, 1
+		if j&1 != 0 {
+			j -= len(childs)
+			jstep, endptrick = 1, 0
+		}
+		// Relabel the even length path from the entry child to the base
+		p := m.labelend[b]
+		for j != 0 {
+			m.label[m.endpoint[p^1]] = 0
+			m.label[m.endpoint[endps[at(j-endptrick)]^endptrick^1]] = 0
+			m.assignLabel(m.endpoint[p^1], 2, p)
+			m.allowedge[endps[at(j-endptrick)]/2] = true
+			j += jstep
+			p = endps[at(j-endptrick)] ^ endptrick
+			m.allowedge[p/2] = true
+			j += jstep
+		}
+		bv := childs[at(j)]
+		m.label[m.endpoint[p^1]], m.label[bv] = 2, 2
+		m.labelend[m.endpoint[p^1]], m.labelend[bv] = p, p
+		m.bestedge[bv] = -1
+		// Children off the path get a T label if a leaf was reached before
+		j += jstep
+		for childs[at(j)] != entrychild {
+			bv = childs[at(j)]
+			if m.label[bv] == 1 {
+				j += jstep
+				continue
+			}
+			for _, leaf := range m.blossomLeaves(bv) {
+				if m.label[leaf] != 0 {
+					m.label[leaf] = 0
+					m.label[m.endpoint[m.mate[m.blossombase[bv]]]] = 0
+					m.assignLabel(leaf, 2, m.labelend[leaf])
+					break
+				}
+			}
+			j += jstep
+		}
+	}
+	m.label[b], m.labelend[b] = -1, -1
+	m.blossomchilds[b], m.blossomendps[b] = nil, nil
+	m.blossombase[b] = -1
+	m.blossombestedges[b] = nil
+	m.bestedge[b] = -1
+	m.unusedblossoms = append(m.unusedblossoms, b)
+}
+
+// augmentBlossom swaps the matched and unmatched edges along the even path
+// from vertex v to the base of blossom b, making v the new base
+func (m *blossomMatching) augmentBlossom(b, v int) {
+	t := v
+	for m.blossomparent[t] != b {
+		t = m.blossomparent[t]
+	}
+	if t >= m.n {
+		m.augmentBlossom(t, v)
+	}
+	childs, endps := m.blossomchilds[b], m.blossomendps[b]
+	at := func(j int) int { return (j + len(childs)) % len(childs) }
+	i := indexOf(childs, t)
+	j := i
+	jstep, endptrick := -1, 1
+	if i&1 != 0 {
+		j -= len(childs)
+		jstep, endptrick = 1, 0
+	}
+	for j != 0 {
+		j += jstep
+		t = childs[at(j)]
+		p := endps[at(j-endptrick)] ^ endptrick
+		if t >= m.n {
+			m.augmentBlossom(t, m.endpoint[p])
+		}
+		j += jstep
+		t = childs[at(j)]
+		if t >= m.n {
+			m.augmentBlossom(t, m.endpoint[p^1])
+		}
+		m.mate[m.endpoint[p]] = p ^ 1
+		m.mate[m.endpoint[p^1]] = p
+	}
+	m.blossomchilds[b] = append(append([]int{}, childs[i:]...), childs[:i]...)
+	m.blossomendps[b] = append(append([]int{}, endps[i:]...), endps[:i]...)
+	m.blossombase[b] = m.blossombase[m.blossomchilds[b][0]]
+}
+
+// augmentMatching flips the augmenting path through edge k, which joins the
+// S vertices of two different trees
+func (m *blossomMatching) augmentMatching(k int) {
+	v, w := m.edges[k][0], m.edges[k][1]
+	for _, start := range [][2]int{{v, 2*k + 1}, {w, 2 * k}} {
+		s, p := start[0], start[1]
+		for {
+			bs := m.inblossom[s]
+			if bs >= m.n {
+				m.augmentBlossom(bs, s)
+			}
+			m.mate[s] = p
+			if m.labelend[bs] == -1 {
+				break // reached the root of the tree
+			}
+			t := m.endpoint[m.labelend[bs]]
+			bt := m.inblossom[t]
+			s = m.endpoint[m.labelend[bt]]
+			j := m.endpoint[m.labelend[bt]^1]
+			if bt >= m.n {
+				m.augmentBlossom(bt, j)
+			}
+			m.mate[j] = m.labelend[bt]
+			p = m.labelend[bt] ^ 1
+		}
+	}
+}
+
+func (m *blossomMatching) solve(maxCardinality bool) []int {
+	n := m.n
+	maxweight := 0
+	for _, e := range m.edges {
+		if e[2] > maxweight {
+			maxweight = e[2]
+		}
+	}
+	m.endpoint = make([]int, 2*len(m.edges))
+	m.neighbend = make([][]int, n)
+	for k, e := range m.edges {
+		m.endpoint[2*k], m.endpoint[2*k+1] = e[0], e[1]
+		m.neighbend[e[0]] = append(m.neighbend[e[0]], 2*k+1)
+		m.neighbend[e[1]] = append(m.neighbend[e[1]], 2*k)
+	}
+	m.mate = filledInts(n, -1)
+	m.label = make([]int, 2*n)
+	m.labelend = filledInts(2*n, -1)
+	m.inblossom = make([]int, n)
+	m.blossomparent = filledInts(2*n, -1)
+	m.blossomchilds = make([][]int, 2*n)
+	m.blossombase = filledInts(2*n, -1)
+	m.blossomendps = make([][]int, 2*n)
+	m.bestedge = filledInts(2*n, -1)
+	m.blossombestedges = make([][]int, 2*n)
+	m.dualvar = make([]int, 2*n)
+	m.allowedge = make([]bool, len(m.edges))
+	for v := 0; v < n; v++ {
+		m.inblossom[v] = v
+		m.blossombase[v] = v
+		m.dualvar[v] = maxweight
+		m.unusedblossoms = append(m.unusedblossoms, n+v)
+	}
+
+	// Each stage either augments the matching by one edge or proves that it
+	// is optimal
+	for stage := 0; stage < n; stage++ {
+		for i := range m.label {
+			m.label[i] = 0
+			m.bestedge[i] = -1
+		}
+		for b := n; b < 2*n; b++ {
+			m.blossombestedges[b] = nil
+		}
+		for k := range m.allowedge {
+			m.allowedge[k] = false
+		}
+		m.queue = m.queue[:0]
+		for v := 0; v < n; v++ {
+			if m.mate[v] == -1 && m.label[m.inblossom[v]] == 0 {
+				m.assignLabel(v, 1, -1)
+			}
+		}
+
+		augmented := false
+		for {
+			// Grow the trees along tight edges from S vertices
+			for len(m.queue) > 0 && !augmented {
+				v := m.queue[len(m.queue)-1]
+				m.queue = m.queue[:len(m.queue)-1]
+				for _, p := range m.neighbend[v] {
+					k := p / 2
+					w := m.endpoint[p]
+					if m.inblossom[v] == m.inblossom[w] {
+						continue
+					}
+					kslack := 0
+					if !m.allowedge[k] {
+						if kslack = m.slack(k); kslack <= 0 {
+							m.allowedge[k] = true
+						}
+					}
+					if m.allowedge[k] {
+						if m.label[m.inblossom[w]] == 0 {
+							m.assignLabel(w, 2, p^1)
+						} else if m.label[m.inblossom[w]] == 1 {
+							if base := m.scanBlossom(v, w); base >= 0 {
+								m.addBlossom(base, k)
+							} else {
+								m.augmentMatching(k)
+								augmented = true
+								break
+							}
+						} else if m.label[w] == 0 {
+							m.label[w] = 2
+							m.labelend[w] = p ^ 1
+						}
+					} else if m.label[m.inblossom[w]] == 1 {
+						if b := m.inblossom[v]; m.bestedge[b] == -1 || kslack < m.slack(m.bestedge[b]) {
+							m.bestedge[b] = k
+						}
+					} else if m.label[w] == 0 {
+						if m.bestedge[w] == -1 || kslack < m.slack(m.bestedge[w]) {
+							m.bestedge[w] = k
+						}
+					}
+				}
+			}
+			if augmented {
+				break
+			}
+
+			// No tight edge is left, so find the largest dual change that
+			// keeps every slack non-negative
+			deltatype, delta, deltaedge, deltablossom := -1, 0, -1, -1
+			if !maxCardinality {
+				deltatype, delta = 1, minInts(m.dualvar[:n])
+			}
+			for v := 0; v < n; v++ {
+				if m.label[m.inblossom[v]] == 0 && m.bestedge[v] != -1 {
+					if d := m.slack(m.bestedge[v]); deltatype == -1 || d < delta {
+						deltatype, delta, deltaedge = 2, d, m.bestedge[v]
+					}
+				}
+			}
+			for b := 0; b < 2*n; b++ {
+				if m.blossomparent[b] == -1 && m.label[b] == 1 && m.bestedge[b] != -1 {
+					if d := m.slack(m.bestedge[b]) / 2; deltatype == -1 || d < delta {
+						deltatype, delta, deltaedge = 3, d, m.bestedge[b]
+					}
+				}
+			}
+			for b := n; b < 2*n; b++ {
+				if m.blossombase[b] >= 0 && m.blossomparent[b] == -1 && m.label[b] == 2 &&
+					(deltatype == -1 || m.dualvar[b] < delta) {
+					deltatype, delta, deltablossom = 4, m.dualvar[b], b
+				}
+			}
+			if deltatype == -1 {
+				// Only possible with maxCardinality: the matching is as large
+				// as it gets, so make a final change to reach optimality
+				deltatype, delta = 1, minInts(m.dualvar[:n])
+				if delta < 0 {
+					delta = 0
+				}
+			}
+
+			for v := 0; v < n; v++ {
+				switch m.label[m.inblossom[v]] {
+				case 1:
+					m.dualvar[v] -= delta
+				case 2:
+					m.dualvar[v] += delta
+				}
+			}
+			for b := n; b < 2*n; b++ {
+				if m.blossombase[b] >= 0 && m.blossomparent[b] == -1 {
+					switch m.label[b] {
+					case 1:
+						m.dualvar[b] += delta
+					case 2:
+						m.dualvar[b] -= delta
+					}
+				}
+			}
+
+			if deltatype == 1 {
+				break // the matching is optimal
+			} else if deltatype == 2 {
+				m.allowedge[deltaedge] = true
+				i, j := m.edges[deltaedge][0], m.edges[deltaedge][1]
+				if m.label[m.inblossom[i]] == 0 {
+					i = j
+				}
+				m.queue = append(m.queue, i)
+			} else if deltatype == 3 {
+				m.allowedge[deltaedge] = true
+				m.queue = append(m.queue, m.edges[deltaedge][0])
+			} else {
+				m.expandBlossom(deltablossom, false)
+			}
+		}
+		if !augmented {
+			break
+		}
+
+		// S blossoms whose dual reached zero are expanded between stages
+		for b := n; b < 2*n; b++ {
+			if m.blossomparent[b] == -1 && m.blossombase[b] >= 0 && m.label[b] == 1 && m.dualvar[b] == 0 {
+				m.expandBlossom(b, true)
+			}
+		}
+	}
+
+	mate := make([]int, n)
+	for v := range mate {
+		mate[v] = -1
+		if m.mate[v] >= 0 {
+			mate[v] = m.endpoint[m.mate[v]]
+		}
+	}
+	return mate
+}
+
+func filledInts(n, value int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = value
+	}
+	return s
+}
+
+func minInts(s []int) int {
+	least := s[0]
+	for _, v := range s[1:] {
+		if v < least {
+			least = v
+		}
+	}
+	return least
+}
+
+func indexOf(s []int, value int) int {
+	for i, v := range s {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
+
+func reverseInts(s []int) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
@@ -0,0 +1,91 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// bruteForceMatching returns the weight of the lightest perfect matching by
+// pairing the first vertex with each of the others in turn
+func bruteForceMatching(vertices []int, weight func(a, b int) int) int {
+	if len(vertices) == 0 {
+		return 0
+	}
+	best := -1
+	for i := 1; i < len(vertices); i++ {
+		rest := append(append([]int{}, vertices[1:i]...), vertices[i+1:]...)
+		if cost := weight(vertices[0], vertices[i]) + bruteForceMatching(rest, weight); best == -1 || cost < best {
+			best = cost
+		}
+	}
+	return best
+}
+
+// checkMatching verifies that pairs match every vertex exactly once and
+// returns their total weight
+func checkMatching(t *testing.T, vertices []int, pairs [][2]int, weight func(a, b int) int) int {
+	t.Helper()
+	matched := make(map[int]int)
+	total := 0
+	for _, pair := range pairs {
+		matched[pair[0]]++
+		matched[pair[1]]++
+		total += weight(pair[0], pair[1])
+	}
+	for _, v := range vertices {
+		if matched[v] != 1 {
+			t.Fatalf("Vertex %d is matched %d times in %v", v, matched[v], pairs)
+		}
+	}
+	if len(pairs) != len(vertices)/2 {
+		t.Fatalf("Matching %v has %d pairs for %d vertices", pairs, len(pairs), len(vertices))
+	}
+	return total
+}
+
+func TestMinWeightPerfectMatching(t *testing.T) {
+	r := rand.New(rand.NewSource(9))
+	for i := 0; i < 300; i++ {
+		n := 2 * r.Intn(6)
+		vertices := r.Perm(3 * n)[:n]
+		w := make(map[[2]int]int)
+		for _, a := range vertices {
+			for _, b := range vertices {
+				if a < b {
+					w[[2]int{a, b}] = r.Intn(20)
+					w[[2]int{b, a}] = w[[2]int{a, b}]
+				}
+			}
+		}
+		weight := func(a, b int) int { return w[[2]int{a, b}] }
+		pairs := minWeightPerfectMatching(vertices, weight)
+		if got, want := checkMatching(t, vertices, pairs, weight), bruteForceMatching(vertices, weight); got != want {
+			t.Errorf("Matching of %v weighs %d, want %d", vertices, got, want)
+		}
+	}
+}
+
+func TestLargeMinWeightPerfectMatching(t *testing.T) {
+	// Points at 0, 10, 11 and 21 on a line are best matched as (0, 10) and
+	// (11, 21); pairing the closest two first costs 22 instead of 20. Eight
+	// such groups far apart give 32 vertices.
+	position := make(map[int]int)
+	vertices := []int{}
+	for group := 0; group < 8; group++ {
+		for i, offset := range []int{0, 10, 11, 21} {
+			v := 4*group + i + 1
+			position[v] = 1000*group + offset
+			vertices = append(vertices, v)
+		}
+	}
+	weight := func(a, b int) int {
+		if position[a] > position[b] {
+			return position[a] - position[b]
+		}
+		return position[b] - position[a]
+	}
+	pairs := minWeightPerfectMatching(vertices, weight)
+	if total := checkMatching(t, vertices, pairs, weight); total != 8*20 {
+		t.Errorf("Matching weighs %d, want %d", total, 8*20)
+	}
+}
@@ -0,0 +1,161 @@
+package graph
+
+// An AdjacencyMatrix is a dense representation of a graph. Rows and columns
+// are indexed by vertex, so row and column 0 are unused. Counts[x][y] is the
+// number of edges from x to y and Weights[x][y] is the lightest of their
+// weights. EdgeWeights[x][y] lists the weight of every one of those edges, so
+// parallel edges keep their own weights. Undirected graphs have symmetric
+// matrices.
+type AdjacencyMatrix struct {
+	N           int
+	Directed    bool
+	Counts      [][]int
+	Weights     [][]int
+	EdgeWeights [][][]int
+}
+
+// NewAdjacencyMatrix creates an empty matrix for the vertices 1..n
+func NewAdjacencyMatrix(n int, directed bool) *AdjacencyMatrix {
+	edgeWeights := make([][][]int, n+1)
+	for i := range edgeWeights {
+		edgeWeights[i] = make([][]int, n+1)
+	}
+	return &AdjacencyMatrix{N: n, Directed: directed, Counts: squareMatrix(n), Weights: squareMatrix(n), EdgeWeights: edgeWeights}
+}
+
+func squareMatrix(n int) [][]int {
+	m := make([][]int, n+1)
+	for i := range m {
+		m[i] = make([]int, n+1)
+	}
+	return m
+}
+
+// AdjacencyMatrix converts the graph into a dense matrix
+func (g *Graph) AdjacencyMatrix() *AdjacencyMatrix {
+	m := NewAdjacencyMatrix(g.nVertices, g.Directed)
+	for _, e := range g.EdgeList() {
+		m.AddEdge(e.X, e.Y, e.Weight)
+	}
+	return m
+}
+
+// AddEdge records an edge from x to y, and from y to x if the matrix is undirected
+func (m *AdjacencyMatrix) AddEdge(x, y, weight int) {
+	for _, cell := range [][2]int{{x, y}, {y, x}} {
+		if m.Counts[cell[0]][cell[1]] == 0 || weight < m.Weights[cell[0]][cell[1]] {
+			m.Weights[cell[0]][cell[1]] = weight
+		}
+		m.Counts[cell[0]][cell[1]]++
+		m.EdgeWeights[cell[0]][cell[1]] = append(m.EdgeWeights[cell[0]][cell[1]], weight)
+		if m.Directed || x == y {
+			break
+		}
+	}
+}
+
+// Graph converts the matrix back into an adjacency list graph
+func (m *AdjacencyMatrix) Graph() *Graph {
+	edges := []Edge{}
+	for x := 1; x <= m.N; x++ {
+		y := 1
+		if !m.Directed {
+			y = x
+		}
+		for ; y <= m.N; y++ {
+			for _, weight := range m.cellWeights(x, y) {
+				edges = append(edges, Edge{X: x, Y: y, Weight: weight})
+			}
+		}
+	}
+	return fromEdges(m.Directed, m.N, edges)
+}
+
+// DegreeMatrix returns the diagonal matrix of vertex out-degrees, or of the
+// total weight leaving each vertex if weighted is set. Self-loops are ignored.
+func (m *AdjacencyMatrix) DegreeMatrix(weighted bool) [][]int {
+	d := squareMatrix(m.N)
+	for x := 1; x <= m.N; x++ {
+		for y := 1; y <= m.N; y++ {
+			if x != y {
+				d[x][x] += m.entry(x, y, weighted)
+			}
+		}
+	}
+	return d
+}
+
+// LaplacianMatrix returns the degree matrix minus the adjacency matrix,
+// counting edges or summing their weights if weighted is set. Self-loops are
+// ignored.
+func (m *AdjacencyMatrix) LaplacianMatrix(weighted bool) [][]int {
+	l := m.DegreeMatrix(weighted)
+	for x := 1; x <= m.N; x++ {
+		for y := 1; y <= m.N; y++ {
+			if x != y {
+				l[x][y] -= m.entry(x, y, weighted)
+			}
+		}
+	}
+	return l
+}
+
+// entry is the number of edges from x to y, or their total weight
+func (m *AdjacencyMatrix) entry(x, y int, weighted bool) int {
+	if !weighted {
+		return m.Counts[x][y]
+	}
+	total := 0
+	for _, weight := range m.cellWeights(x, y) {
+		total += weight
+	}
+	return total
+}
+
+// cellWeights returns the weight of each edge from x to y. Cells whose
+// EdgeWeights were not filled in, such as those set by hand, give every edge
+// the weight in Weights.
+func (m *AdjacencyMatrix) cellWeights(x, y int) []int {
+	if m.EdgeWeights != nil && len(m.EdgeWeights[x][y]) == m.Counts[x][y] {
+		return m.EdgeWeights[x][y]
+	}
+	weights := make([]int, m.Counts[x][y])
+	for k := range weights {
+		weights[k] = m.Weights[x][y]
+	}
+	return weights
+}
+
+// CountWalks returns the matrix whose entry [x][y] is the number of walks of
+// exactly k edges from x to y, computed as the k-th power of the adjacency
+// matrix by repeated squaring
+func (m *AdjacencyMatrix) CountWalks(k int) [][]int {
+	result := squareMatrix(m.N)
+	for i := 1; i <= m.N; i++ {
+		result[i][i] = 1
+	}
+	base := m.Counts
+	for ; k > 0; k >>= 1 {
+		if k&1 == 1 {
+			result = multiplyMatrices(result, base)
+		}
+		base = multiplyMatrices(base, base)
+	}
+	return result
+}
+
+func multiplyMatrices(a, b [][]int) [][]int {
+	n := len(a) - 1
+	c := squareMatrix(n)
+	for i := 1; i <= n; i++ {
+		for k := 1; k <= n; k++ {
+			if a[i][k] == 0 {
+				continue
+			}
+			for j := 1; j <= n; j++ {
+				c[i][j] += a[i][k] * b[k][j]
+			}
+		}
+	}
+	return c
+}
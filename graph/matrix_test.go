@@ -0,0 +1,139 @@
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestAdjacencyMatrix(t *testing.T) {
+	g := NewGraph(false)
+	g.InsertWeightedEdge(1, 2, 4, false)
+	g.InsertWeightedEdge(2, 3, 7, false)
+	g.InsertWeightedEdge(3, 3, 1, false)
+	m := g.AdjacencyMatrix()
+	if m.N != 3 || m.Counts[1][2] != 1 || m.Counts[2][1] != 1 || m.Weights[2][3] != 7 || m.Weights[3][2] != 7 {
+		t.Error("Incorrect matrix of an undirected graph")
+	}
+	if m.Counts[3][3] != 1 || m.Counts[1][3] != 0 {
+		t.Error("Self-loops should occupy the diagonal once")
+	}
+
+	d := NewGraph(true)
+	d.InsertWeightedEdge(1, 2, 5, true)
+	d.InsertWeightedEdge(1, 2, 3, true)
+	dm := d.AdjacencyMatrix()
+	if dm.Counts[1][2] != 2 || dm.Weights[1][2] != 3 || dm.Counts[2][1] != 0 {
+		t.Error("Parallel edges should be counted and keep the lightest weight")
+	}
+	if !reflect.DeepEqual(dm.EdgeWeights[1][2], []int{3, 5}) {
+		t.Errorf("Parallel edges should keep their own weights, got %v", dm.EdgeWeights[1][2])
+	}
+}
+
+func TestAdjacencyMatrixRoundTrip(t *testing.T) {
+	for _, directed := range []bool{false, true} {
+		g := initGraph(directed)
+		h := g.AdjacencyMatrix().Graph()
+		if !reflect.DeepEqual(sortedEdges(g), sortedEdges(h)) {
+			t.Errorf("Round trip changed the edges of graph1 (directed: %v)", directed)
+		}
+	}
+	g := randomWeightedGraph(12, 0.4, 3)
+	if !reflect.DeepEqual(g.AdjacencyMatrix(), g.AdjacencyMatrix().Graph().AdjacencyMatrix()) {
+		t.Error("Round trip should keep weights")
+	}
+
+	for _, directed := range []bool{false, true} {
+		p := NewGraph(directed)
+		p.InsertWeightedEdge(1, 2, 1, directed)
+		p.InsertWeightedEdge(1, 2, 10, directed)
+		p.InsertWeightedEdge(2, 3, 4, directed)
+		p.InsertWeightedEdge(2, 3, 4, directed)
+		p.InsertWeightedEdge(3, 3, 6, directed)
+		if h := p.AdjacencyMatrix().Graph(); !reflect.DeepEqual(sortedEdges(p), sortedEdges(h)) {
+			t.Errorf("Round trip changed parallel edge weights (directed: %v): %v", directed, sortedEdges(h))
+		}
+	}
+}
+
+func TestLaplacianMatrix(t *testing.T) {
+	g := NewGraph(false)
+	g.InsertWeightedEdge(1, 2, 2, false)
+	g.InsertWeightedEdge(2, 3, 5, false)
+	g.InsertWeightedEdge(2, 2, 9, false)
+	m := g.AdjacencyMatrix()
+	if d := m.DegreeMatrix(false); d[1][1] != 1 || d[2][2] != 2 || d[3][3] != 1 || d[1][2] != 0 {
+		t.Errorf("Incorrect degree matrix: %v", d)
+	}
+	want := [][]int{{0, 0, 0, 0}, {0, 2, -2, 0}, {0, -2, 7, -5}, {0, 0, -5, 5}}
+	if l := m.LaplacianMatrix(true); !reflect.DeepEqual(l, want) {
+		t.Errorf("Incorrect weighted Laplacian: %v", l)
+	}
+
+	// Parallel edges add their weights
+	p := NewGraph(false)
+	p.InsertWeightedEdge(1, 2, 1, false)
+	p.InsertWeightedEdge(1, 2, 10, false)
+	want = [][]int{{0, 0, 0}, {0, 11, -11}, {0, -11, 11}}
+	if l := p.AdjacencyMatrix().LaplacianMatrix(true); !reflect.DeepEqual(l, want) {
+		t.Errorf("Incorrect weighted Laplacian with parallel edges: %v", l)
+	}
+
+	// Matrices filled in by hand weigh every edge by Weights
+	h := NewAdjacencyMatrix(2, true)
+	h.Counts[1][2], h.Weights[1][2] = 3, 2
+	if d := h.DegreeMatrix(true); d[1][1] != 6 {
+		t.Errorf("Incorrect weighted degree %v of a hand built matrix", d[1][1])
+	}
+
+	// Rows of a Laplacian always sum to zero
+	r := ErdosRenyi(15, 0.3, rand.NewSource(1)).AdjacencyMatrix().LaplacianMatrix(false)
+	for x := 1; x <= 15; x++ {
+		sum := 0
+		for _, v := range r[x] {
+			sum += v
+		}
+		if sum != 0 {
+			t.Errorf("Row %v of the Laplacian sums to %v", x, sum)
+		}
+	}
+}
+
+func TestCountWalks(t *testing.T) {
+	m := CycleGraph(4).AdjacencyMatrix()
+	w := m.CountWalks(2)
+	if w[1][1] != 2 || w[1][3] != 2 || w[1][2] != 0 {
+		t.Errorf("Incorrect walks of length 2 on C4: %v", w[1])
+	}
+	if w := m.CountWalks(0); w[2][2] != 1 || w[2][3] != 0 {
+		t.Error("Walks of length 0 should form the identity")
+	}
+	// Closed walks of length 3 count each triangle six times
+	k := CompleteGraph(4).AdjacencyMatrix().CountWalks(3)
+	trace := 0
+	for v := 1; v <= 4; v++ {
+		trace += k[v][v]
+	}
+	if trace != 6*4 {
+		t.Errorf("K4 should have 4 triangles, trace is %v", trace)
+	}
+
+	d := initGraph(true).AdjacencyMatrix().CountWalks(3)
+	if d[1][4] != 1 || d[1][5] != 0 {
+		t.Error("Incorrect directed walk counts on graph1")
+	}
+}
+
+// sortedEdges lists the edges of g by endpoints, without their ids
+func sortedEdges(g *Graph) []Edge {
+	edges := g.EdgeList()
+	for i := range edges {
+		edges[i].ID = 0
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		return edges[i].X < edges[j].X || edges[i].X == edges[j].X && edges[i].Y < edges[j].Y
+	})
+	return edges
+}
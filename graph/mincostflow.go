@@ -0,0 +1,179 @@
+package graph
+
+import (
+	"container/heap"
+	"errors"
+)
+
+// A FlowNetwork is a directed network whose edges carry both a capacity and
+// a cost per unit of flow, for solving minimum cost flow problems
+type FlowNetwork struct {
+	net  *flowNetwork
+	arcs []int // forward arc of each edge, indexed by edge id
+	caps []int // original capacity of each edge
+}
+
+// NewFlowNetwork creates an empty network on the vertices 1..n
+func NewFlowNetwork(n int) *FlowNetwork {
+	return &FlowNetwork{net: newFlowNetwork(n)}
+}
+
+// AddEdge adds a directed edge and returns its id for use with Flow
+func (f *FlowNetwork) AddEdge(from, to, capacity, cost int) (int, error) {
+	if from < 1 || from > f.net.n || to < 1 || to > f.net.n {
+		return 0, errors.New("Edge endpoint is not in the network")
+	}
+	if capacity < 0 {
+		return 0, errors.New("Edge capacities must not be negative")
+	}
+	f.arcs = append(f.arcs, f.net.addCostArc(from, to, capacity, 0, cost))
+	f.caps = append(f.caps, capacity)
+	return len(f.arcs) - 1, nil
+}
+
+// Flow returns the flow currently sent along an edge
+func (f *FlowNetwork) Flow(id int) int {
+	return f.caps[id] - f.net.cap[f.arcs[id]]
+}
+
+// MinCostMaxFlow sends as much flow as possible from source to sink and,
+// among all maximum flows, picks one of least total cost. It returns the
+// amount of flow and its cost.
+func (f *FlowNetwork) MinCostMaxFlow(source, sink int) (int, int, error) {
+	return f.successiveShortestPaths(source, sink, maxCapacity)
+}
+
+// MinCostFlow sends exactly demand units of flow from source to sink as
+// cheaply as possible and returns the cost
+func (f *FlowNetwork) MinCostFlow(source, sink, demand int) (int, error) {
+	flow, cost, err := f.successiveShortestPaths(source, sink, demand)
+	if err != nil {
+		return 0, err
+	}
+	if flow < demand {
+		return 0, errors.New("Network cannot carry the requested flow")
+	}
+	return cost, nil
+}
+
+// successiveShortestPaths repeatedly augments along a cheapest residual path.
+// Vertex potentials keep reduced arc costs non-negative so each path can be
+// found with Dijkstra's algorithm; they start from a Bellman-Ford pass so
+// negative edge costs are allowed as long as there is no negative cycle.
+func (f *FlowNetwork) successiveShortestPaths(source, sink, limit int) (int, int, error) {
+	net := f.net
+	if source < 1 || source > net.n || sink < 1 || sink > net.n || source == sink {
+		return 0, 0, errors.New("Source and sink must be distinct vertices of the network")
+	}
+	potential, err := net.bellmanFord(source)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	flow, cost := 0, 0
+	dist := make([]int, net.n+1)
+	prevArc := make([]int, net.n+1)
+	for flow < limit {
+		net.dijkstra(source, potential, dist, prevArc)
+		if dist[sink] == maxCapacity {
+			break
+		}
+		for v := 1; v <= net.n; v++ {
+			if dist[v] != maxCapacity {
+				potential[v] += dist[v]
+			}
+		}
+		push := limit - flow
+		for v := sink; v != source; v = net.to[prevArc[v]^1] {
+			if net.cap[prevArc[v]] < push {
+				push = net.cap[prevArc[v]]
+			}
+		}
+		for v := sink; v != source; v = net.to[prevArc[v]^1] {
+			net.cap[prevArc[v]] -= push
+			net.cap[prevArc[v]^1] += push
+			cost += push * net.cost[prevArc[v]]
+		}
+		flow += push
+	}
+	return flow, cost, nil
+}
+
+// bellmanFord returns the cheapest cost from source to every vertex through
+// arcs with capacity (0 for unreachable vertices), failing on negative cycles
+func (f *flowNetwork) bellmanFord(source int) ([]int, error) {
+	dist := make([]int, f.n+1)
+	reached := make([]bool, f.n+1)
+	reached[source] = true
+	for round := 0; round < f.n; round++ {
+		changed := false
+		for x := 1; x <= f.n; x++ {
+			if !reached[x] {
+				continue
+			}
+			for a := f.head[x]; a != -1; a = f.next[a] {
+				y := f.to[a]
+				if f.cap[a] > 0 && (!reached[y] || dist[x]+f.cost[a] < dist[y]) {
+					dist[y] = dist[x] + f.cost[a]
+					reached[y] = true
+					changed = true
+				}
+			}
+		}
+		if !changed {
+			return dist, nil
+		}
+	}
+	return nil, errors.New("Network has a negative cost cycle")
+}
+
+// dijkstra finds cheapest residual paths from source using reduced costs,
+// filling dist (maxCapacity when unreachable) and the arc used to enter each
+// vertex
+func (f *flowNetwork) dijkstra(source int, potential, dist, prevArc []int) {
+	for i := range dist {
+		dist[i] = maxCapacity
+		prevArc[i] = -1
+	}
+	dist[source] = 0
+	pq := &distanceQueue{{source, 0}}
+	for pq.Len() > 0 {
+		item := heap.Pop(pq).(vertexDistance)
+		v := item.v
+		if item.d > dist[v] {
+			continue // stale entry
+		}
+		for a := f.head[v]; a != -1; a = f.next[a] {
+			y := f.to[a]
+			if f.cap[a] <= 0 {
+				continue
+			}
+			d := dist[v] + f.cost[a] + potential[v] - potential[y]
+			if d < dist[y] {
+				dist[y] = d
+				prevArc[y] = a
+				heap.Push(pq, vertexDistance{y, d})
+			}
+		}
+	}
+}
+
+// vertexDistance is an entry of a distanceQueue
+type vertexDistance struct {
+	v int
+	d int
+}
+
+// distanceQueue is a min-heap of vertices keyed by tentative distance
+type distanceQueue []vertexDistance
+
+func (q distanceQueue) Len() int            { return len(q) }
+func (q distanceQueue) Less(i, j int) bool  { return q[i].d < q[j].d }
+func (q distanceQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *distanceQueue) Push(x interface{}) { *q = append(*q, x.(vertexDistance)) }
+func (q *distanceQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
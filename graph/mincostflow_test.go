@@ -0,0 +1,49 @@
+package graph
+
+import "testing"
+
+func TestMinCostMaxFlow(t *testing.T) {
+	// Two routes from 1 to 4: a cheap one limited to 2 units and a dear one
+	f := NewFlowNetwork(4)
+	cheap, _ := f.AddEdge(1, 2, 2, 1)
+	f.AddEdge(2, 4, 5, 1)
+	dear, _ := f.AddEdge(1, 3, 5, 4)
+	f.AddEdge(3, 4, 1, 4)
+	flow, cost, err := f.MinCostMaxFlow(1, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if flow != 3 || cost != 2*2+8 {
+		t.Errorf("Incorrect min cost max flow: flow %v cost %v", flow, cost)
+	}
+	if f.Flow(cheap) != 2 || f.Flow(dear) != 1 {
+		t.Error("Incorrect flow on edges")
+	}
+}
+
+func TestMinCostFlow(t *testing.T) {
+	f := NewFlowNetwork(3)
+	f.AddEdge(1, 2, 4, 2)
+	f.AddEdge(2, 3, 4, -1) // negative costs are fine without negative cycles
+	f.AddEdge(1, 3, 4, 3)
+	cost, err := f.MinCostFlow(1, 3, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cost != 4*1+2*3 {
+		t.Errorf("Incorrect min cost flow cost %v", cost)
+	}
+	g := NewFlowNetwork(3)
+	g.AddEdge(1, 2, 4, 2)
+	if _, err := g.MinCostFlow(1, 3, 1); err == nil {
+		t.Error("Met a demand the network cannot carry")
+	}
+
+	cycle := NewFlowNetwork(3)
+	cycle.AddEdge(1, 2, 1, 1)
+	cycle.AddEdge(2, 3, 1, -2)
+	cycle.AddEdge(3, 2, 1, -2)
+	if _, _, err := cycle.MinCostMaxFlow(1, 3); err == nil {
+		t.Error("Did not detect a negative cost cycle")
+	}
+}
@@ -0,0 +1,171 @@
+package graph
+
+import (
+	"errors"
+	"sort"
+)
+
+// GlobalMinCut finds the minimum cut of an undirected graph using edge weights
+// as capacities: the lightest set of edges whose removal disconnects the
+// graph. It returns the cut weight and the vertices on one side of it.
+// Stoer-Wagner runs in O(n^3) time on a dense weight matrix.
+func (g *Graph) GlobalMinCut() (int, []int, error) {
+	if g.Directed {
+		return 0, nil, errors.New("Global minimum cut requires an undirected graph")
+	}
+	if g.nVertices < 2 {
+		return 0, nil, errors.New("Graph needs at least two vertices to be cut")
+	}
+	n := g.nVertices
+	w := make([][]int, n+1)
+	for i := range w {
+		w[i] = make([]int, n+1)
+	}
+	for _, e := range g.EdgeList() {
+		if e.Weight < 0 {
+			return 0, nil, errors.New("Edge capacities must not be negative")
+		}
+		if e.X != e.Y {
+			w[e.X][e.Y] += e.Weight
+			w[e.Y][e.X] += e.Weight
+		}
+	}
+
+	merged := make(map[int][]int) // original vertices folded into each vertex
+	active := []int{}
+	for v := 1; v <= n; v++ {
+		merged[v] = []int{v}
+		active = append(active, v)
+	}
+	best, bestSide := -1, []int{}
+	for len(active) > 1 {
+		// Minimum cut phase: grow a set by always adding the most tightly
+		// connected vertex; the last one added is cut off from the rest
+		added := make(map[int]bool)
+		connection := make(map[int]int)
+		prev, last := -1, -1
+		for range active {
+			next := -1
+			for _, v := range active {
+				if !added[v] && (next == -1 || connection[v] > connection[next]) {
+					next = v
+				}
+			}
+			added[next] = true
+			for _, v := range active {
+				if !added[v] {
+					connection[v] += w[next][v]
+				}
+			}
+			prev, last = last, next
+		}
+		if best == -1 || connection[last] < best {
+			best = connection[last]
+			bestSide = append([]int{}, merged[last]...)
+		}
+
+		// Merge the last vertex into the one added before it
+		merged[prev] = append(merged[prev], merged[last]...)
+		for _, v := range active {
+			w[prev][v] += w[last][v]
+			w[v][prev] = w[prev][v]
+		}
+		w[prev][prev] = 0
+		for i, v := range active {
+			if v == last {
+				active = append(active[:i], active[i+1:]...)
+				break
+			}
+		}
+	}
+	sort.Ints(bestSide)
+	return best, bestSide, nil
+}
+
+// A GomoryHuTree summarizes the minimum cuts between every pair of vertices
+// of an undirected graph. The minimum cut between two vertices equals the
+// lightest edge on the tree path joining them, and removing that edge splits
+// the tree into the two sides of such a cut.
+type GomoryHuTree struct {
+	Parent map[int]int // Tree neighbour of each vertex towards vertex 1 (-1 for vertex 1)
+	Weight map[int]int // Weight of the edge between each vertex and its Parent
+}
+
+// GomoryHuTree builds the cut tree with Gusfield's algorithm, which needs
+// n-1 maximum flow computations and no graph contractions
+func (g *Graph) GomoryHuTree() (*GomoryHuTree, error) {
+	if g.Directed {
+		return nil, errors.New("Gomory-Hu trees require an undirected graph")
+	}
+	n := g.nVertices
+	parent := map[int]int{1: -1}
+	weight := make(map[int]int)
+	for v := 2; v <= n; v++ {
+		parent[v] = 1
+	}
+	for s := 2; s <= n; s++ {
+		t := parent[s]
+		f, err := g.capacityNetwork()
+		if err != nil {
+			return nil, err
+		}
+		value := f.maxFlow(s, t)
+		sourceSide := make(map[int]bool)
+		for _, v := range f.reachable(s) {
+			sourceSide[v] = true
+		}
+		weight[s] = value
+		for v := 1; v <= n; v++ {
+			if v != s && sourceSide[v] && parent[v] == t {
+				parent[v] = s
+			}
+		}
+		// Keep the tree a cut tree when the cut also separates t from its parent
+		if pt := parent[t]; pt != -1 && sourceSide[pt] {
+			parent[s], parent[t] = pt, s
+			weight[s], weight[t] = weight[t], value
+		}
+	}
+	return &GomoryHuTree{Parent: parent, Weight: weight}, nil
+}
+
+// MinCut returns the minimum cut weight between two distinct vertices
+func (t *GomoryHuTree) MinCut(u, v int) (int, error) {
+	if _, ok := t.Parent[u]; !ok {
+		return 0, errors.New("Vertex is not in the tree")
+	}
+	if _, ok := t.Parent[v]; !ok {
+		return 0, errors.New("Vertex is not in the tree")
+	}
+	if u == v {
+		return 0, errors.New("Vertices must differ")
+	}
+	depth := func(x int) int {
+		d := 0
+		for ; t.Parent[x] != -1; x = t.Parent[x] {
+			d++
+		}
+		return d
+	}
+	du, dv := depth(u), depth(v)
+	lightest := -1
+	lighter := func(x int) {
+		if lightest == -1 || t.Weight[x] < lightest {
+			lightest = t.Weight[x]
+		}
+	}
+	for ; du > dv; du-- {
+		lighter(u)
+		u = t.Parent[u]
+	}
+	for ; dv > du; dv-- {
+		lighter(v)
+		v = t.Parent[v]
+	}
+	for u != v {
+		lighter(u)
+		lighter(v)
+		u, v = t.Parent[u], t.Parent[v]
+	}
+	return lightest, nil
+}
@@ -0,0 +1,67 @@
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func randomWeightedGraph(n int, p float64, seed int64) *Graph {
+	r := rand.New(rand.NewSource(seed))
+	g := NewGraph(false)
+	g.InsertVertex(n)
+	for _, e := range ErdosRenyi(n, p, r).EdgeList() {
+		g.InsertWeightedEdge(e.X, e.Y, r.Intn(10)+1, false)
+	}
+	return g
+}
+
+func TestGlobalMinCut(t *testing.T) {
+	// Two triangles joined by a single light edge
+	g := NewGraph(false)
+	for _, e := range [][3]int{{1, 2, 5}, {2, 3, 5}, {1, 3, 5}, {4, 5, 5}, {5, 6, 5}, {4, 6, 5}, {3, 4, 2}} {
+		g.InsertWeightedEdge(e[0], e[1], e[2], false)
+	}
+	value, side, err := g.GlobalMinCut()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 2 || !(reflect.DeepEqual(side, []int{1, 2, 3}) || reflect.DeepEqual(side, []int{4, 5, 6})) {
+		t.Errorf("Incorrect global minimum cut %v %v", value, side)
+	}
+
+	for seed := int64(1); seed <= 5; seed++ {
+		g := randomWeightedGraph(12, 0.4, seed)
+		value, _, _ := g.GlobalMinCut()
+		best := -1
+		for v := 2; v <= 12; v++ {
+			if f, _, _ := g.MaxFlow(1, v); best == -1 || f < best {
+				best = f
+			}
+		}
+		if value != best {
+			t.Errorf("Stoer-Wagner found %v but the smallest s-t cut is %v", value, best)
+		}
+	}
+}
+
+func TestGomoryHuTree(t *testing.T) {
+	for seed := int64(1); seed <= 5; seed++ {
+		g := randomWeightedGraph(10, 0.4, seed)
+		tree, err := g.GomoryHuTree()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for u := 1; u <= 10; u++ {
+			for v := u + 1; v <= 10; v++ {
+				expected, _, _ := g.MaxFlow(u, v)
+				if got, _ := tree.MinCut(u, v); got != expected {
+					t.Errorf("Tree gives cut %v between %v and %v, max flow is %v", got, u, v, expected)
+				}
+			}
+		}
+	}
+	if _, err := initGraph(true).GomoryHuTree(); err == nil {
+		t.Error("Built a Gomory-Hu tree for a directed graph")
+	}
+}
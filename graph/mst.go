@@ -0,0 +1,42 @@
+package graph
+
+import (
+	"errors"
+	"sort"
+)
+
+// MinimumSpanningTree returns the edges of a minimum spanning forest of an
+// undirected graph, one tree per connected component, and their total weight.
+// It uses Kruskal's algorithm, so the edges come out by increasing weight.
+func (g *Graph) MinimumSpanningTree() ([]Edge, int, error) {
+	if g.Directed {
+		return nil, 0, errors.New("Minimum spanning tree requires an undirected graph")
+	}
+	edges := g.EdgeList()
+	sort.SliceStable(edges, func(i, j int) bool {
+		return edges[i].Weight < edges[j].Weight
+	})
+	parent := make([]int, g.nVertices+1)
+	for v := range parent {
+		parent[v] = v
+	}
+	var find func(v int) int
+	find = func(v int) int {
+		if parent[v] != v {
+			parent[v] = find(parent[v])
+		}
+		return parent[v]
+	}
+	tree := []Edge{}
+	total := 0
+	for _, e := range edges {
+		rx, ry := find(e.X), find(e.Y)
+		if rx == ry {
+			continue
+		}
+		parent[rx] = ry
+		tree = append(tree, e)
+		total += e.Weight
+	}
+	return tree, total, nil
+}
@@ -0,0 +1,34 @@
+package graph
+
+import "testing"
+
+func TestMinimumSpanningTree(t *testing.T) {
+	g := NewGraph(false)
+	g.InsertWeightedEdge(1, 2, 4, false)
+	g.InsertWeightedEdge(2, 3, 1, false)
+	g.InsertWeightedEdge(1, 3, 2, false)
+	g.InsertWeightedEdge(3, 4, 5, false)
+	g.InsertWeightedEdge(2, 4, 3, false)
+	g.InsertWeightedEdge(5, 6, 7, false)
+	g.InsertWeightedEdge(5, 6, 6, false)
+	tree, total, err := g.MinimumSpanningTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tree) != 4 || total != 12 {
+		t.Errorf("Incorrect spanning forest %v of weight %v", tree, total)
+	}
+	for i := 1; i < len(tree); i++ {
+		if tree[i].Weight < tree[i-1].Weight {
+			t.Error("Edges should come out by increasing weight")
+		}
+	}
+
+	// Every spanning tree of an unweighted graph has n - 1 edges per component
+	if tree, _, _ := initGraph(false).MinimumSpanningTree(); len(tree) != 8 {
+		t.Errorf("Expected 8 edges in the spanning forest of graph1, got %v", len(tree))
+	}
+	if _, _, err := initGraph(true).MinimumSpanningTree(); err == nil {
+		t.Error("Directed graphs should be rejected")
+	}
+}
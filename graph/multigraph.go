@@ -0,0 +1,112 @@
+package graph
+
+import "errors"
+
+// EdgeIDs returns the ids of every edge from x to y in adjacency list order.
+// Multigraphs may hold several; simple graphs hold at most one.
+func (g *Graph) EdgeIDs(x, y int) []int {
+	ids := []int{}
+	seen := make(map[int]bool)
+	for edgeNode := g.Edges[x]; edgeNode != nil; edgeNode = edgeNode.Next {
+		// An undirected self-loop appears twice in the same list
+		if edgeNode.Y == y && !seen[edgeNode.ID] {
+			seen[edgeNode.ID] = true
+			ids = append(ids, edgeNode.ID)
+		}
+	}
+	return ids
+}
+
+// Edge returns the edge with the given id
+func (g *Graph) Edge(id int) (Edge, bool) {
+	nodes := g.edgeNodes(id)
+	if len(nodes) == 0 {
+		return Edge{}, false
+	}
+	ends := g.edgeEnds[id]
+	return Edge{ID: id, X: ends[0], Y: ends[1], Weight: nodes[0].Weight, Attributes: nodes[0].Attributes}, true
+}
+
+// RemoveEdge deletes the edge with the given id, in both directions if it is
+// undirected, leaving any parallel edges in place
+func (g *Graph) RemoveEdge(id int) error {
+	ends, ok := g.edgeEnds[id]
+	if !ok {
+		return errors.New("Edge does not exist")
+	}
+	g.unlinkEdgeNodes(id, ends[0])
+	if ends[1] != ends[0] {
+		g.unlinkEdgeNodes(id, ends[1])
+	}
+	delete(g.edgeEnds, id)
+	g.nEdges--
+	return nil
+}
+
+// unlinkEdgeNodes removes every entry with the given id from the adjacency
+// list of x
+func (g *Graph) unlinkEdgeNodes(id, x int) {
+	link := g.Edges[x]
+	var prev *EdgeNode
+	for edgeNode := link; edgeNode != nil; edgeNode = edgeNode.Next {
+		if edgeNode.ID != id {
+			prev = edgeNode
+			continue
+		}
+		if prev == nil {
+			g.Edges[x] = edgeNode.Next
+		} else {
+			prev.Next = edgeNode.Next
+		}
+		g.Degree[x]--
+	}
+}
+
+// SetEdgeWeight changes the weight of the edge with the given id
+func (g *Graph) SetEdgeWeight(id, weight int) error {
+	nodes := g.edgeNodes(id)
+	if len(nodes) == 0 {
+		return errors.New("Edge does not exist")
+	}
+	for _, edgeNode := range nodes {
+		edgeNode.Weight = weight
+	}
+	return nil
+}
+
+// SetEdgeAttributeByID stores a key/value pair on the edge with the given id
+func (g *Graph) SetEdgeAttributeByID(id int, key, value string) error {
+	nodes := g.edgeNodes(id)
+	if len(nodes) == 0 {
+		return errors.New("Edge does not exist")
+	}
+	if nodes[0].Attributes == nil {
+		attributes := make(Attributes)
+		for _, edgeNode := range nodes {
+			edgeNode.Attributes = attributes
+		}
+	}
+	nodes[0].Attributes[key] = value
+	return nil
+}
+
+// edgeNodes returns the adjacency list entries of the edge with the given id:
+// one for a directed edge and two for an undirected one
+func (g *Graph) edgeNodes(id int) []*EdgeNode {
+	ends, ok := g.edgeEnds[id]
+	if !ok {
+		return nil
+	}
+	nodes := []*EdgeNode{}
+	for i, x := range ends {
+		if i == 1 && x == ends[0] {
+			break
+		}
+		for edgeNode := g.Edges[x]; edgeNode != nil; edgeNode = edgeNode.Next {
+			if edgeNode.ID == id {
+				nodes = append(nodes, edgeNode)
+			}
+		}
+	}
+	return nodes
+}
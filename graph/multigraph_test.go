@@ -0,0 +1,143 @@
+package graph
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParallelEdges(t *testing.T) {
+	g := NewGraph(false)
+	a, _ := g.AddEdge(1, 2, 5)
+	b, _ := g.AddEdge(2, 1, 7)
+	c, _ := g.AddEdge(2, 2, 1)
+	if a == b || b == c || g.NumEdges() != 3 {
+		t.Fatal("Every edge of a multigraph should get its own id")
+	}
+	if ids := g.EdgeIDs(1, 2); !reflect.DeepEqual(ids, []int{b, a}) {
+		t.Errorf("Incorrect parallel edge ids: %v", ids)
+	}
+	if ids := g.EdgeIDs(2, 2); !reflect.DeepEqual(ids, []int{c}) {
+		t.Errorf("A self-loop should be listed once: %v", ids)
+	}
+	if g.Degree[1] != 2 || g.Degree[2] != 4 {
+		t.Errorf("Parallel edges count once and self-loops twice: %v", g.Degree)
+	}
+
+	if err := g.SetEdgeWeight(a, 9); err != nil {
+		t.Fatal(err)
+	}
+	if e, _ := g.Edge(a); e.Weight != 9 || e.X != 1 || e.Y != 2 {
+		t.Errorf("Incorrect edge %v", e)
+	}
+	if e, _ := g.Edge(b); e.Weight != 7 {
+		t.Error("Changing one parallel edge changed another")
+	}
+	g.SetEdgeAttributeByID(b, "name", "b")
+	if name, _ := g.EdgeAttribute(1, 2, "name"); name != "b" {
+		t.Error("Attribute set by id is missing")
+	}
+
+	if err := g.RemoveEdge(b); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.RemoveEdge(b); err == nil {
+		t.Error("Removed an edge twice")
+	}
+	if _, ok := g.Edge(b); ok || g.NumEdges() != 2 || g.Degree[1] != 1 || g.Degree[2] != 3 {
+		t.Error("Edge was not removed in both directions")
+	}
+	if name, ok := g.EdgeAttribute(2, 1, "name"); ok {
+		t.Errorf("The remaining parallel edge should have no name, has %v", name)
+	}
+	g.RemoveEdge(c)
+	if g.Degree[2] != 1 || g.Edges[2].Y != 1 {
+		t.Error("Self-loop was not removed from the adjacency list")
+	}
+}
+
+func TestSimpleGraph(t *testing.T) {
+	g := NewSimpleGraph(false)
+	if !g.Simple() || NewGraph(false).Simple() {
+		t.Error("Incorrect graph mode")
+	}
+	id, err := g.AddEdge(1, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.AddEdge(2, 1, 3); err == nil {
+		t.Error("Simple graph accepted a parallel edge")
+	}
+	if _, err := g.AddEdge(3, 3, 0); err == nil {
+		t.Error("Simple graph accepted a self-loop")
+	}
+	g.InsertEdgeWithAttributes(2, 1, 8, Attributes{"kind": "road"}, false)
+	g.InsertEdge(4, 4, false)
+	if g.NumEdges() != 1 || g.Degree[1] != 1 || g.Degree[4] != 0 {
+		t.Error("Duplicates should be merged and self-loops ignored")
+	}
+	if e, _ := g.Edge(id); e.Weight != 8 || e.Attributes["kind"] != "road" {
+		t.Errorf("Merged edge was not updated: %v", e)
+	}
+	if !g.Transpose().Simple() || !g.InducedSubgraph([]int{1, 2}).Simple() {
+		t.Error("Derived graphs should keep the simple mode")
+	}
+}
+
+func TestCycleSemantics(t *testing.T) {
+	for _, c := range []struct {
+		name     string
+		directed bool
+		edges    [][2]int
+		cycle    bool
+	}{
+		{"undirected path", false, [][2]int{{1, 2}, {2, 3}}, false},
+		{"undirected self-loop", false, [][2]int{{1, 2}, {2, 2}}, true},
+		{"undirected parallel edges", false, [][2]int{{1, 2}, {2, 3}, {3, 2}}, true},
+		{"undirected triangle", false, [][2]int{{1, 2}, {2, 3}, {3, 1}}, true},
+		{"directed diamond", true, [][2]int{{1, 2}, {1, 3}, {2, 4}, {3, 4}, {1, 4}}, false},
+		{"directed parallel edges", true, [][2]int{{1, 2}, {1, 2}}, false},
+		{"directed self-loop", true, [][2]int{{1, 2}, {2, 2}}, true},
+		{"directed 2-cycle", true, [][2]int{{1, 2}, {2, 1}}, true},
+	} {
+		g := NewGraph(c.directed)
+		for _, e := range c.edges {
+			g.InsertEdge(e[0], e[1], c.directed)
+		}
+		g.InitSearch()
+		if _, err := g.FindCycles(1); (err == nil) != c.cycle {
+			t.Errorf("FindCycles on a %v should report a cycle: %v", c.name, c.cycle)
+		}
+	}
+}
+
+func TestComponentsIgnoreParallelEdges(t *testing.T) {
+	g := initGraph(false)
+	want := g.ConnectedComponents()
+	g.InsertEdge(1, 2, false)
+	g.InsertEdge(8, 8, false)
+	if got := g.ConnectedComponents(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Components changed from %v to %v", want, got)
+	}
+}
+
+func TestEdgeIDsSurviveCopies(t *testing.T) {
+	g := NewSimpleGraph(true)
+	g.AddEdge(1, 2, 1)
+	id, _ := g.AddEdge(2, 3, 1)
+	g.RemoveEdge(1)
+	data, _ := json.Marshal(g)
+	h := NewGraph(false)
+	if err := json.Unmarshal(data, h); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := h.Edge(id); !ok || !h.Simple() {
+		t.Errorf("Decoding lost edge ids or the graph mode: %s", data)
+	}
+	if next, _ := h.AddEdge(1, 3, 0); next == id {
+		t.Error("Decoded graph reused an edge id")
+	}
+	if e := g.InducedSubgraph([]int{2, 3}).EdgeList(); e[0].ID != id {
+		t.Error("Subgraphs should keep edge ids")
+	}
+}
@@ -0,0 +1,145 @@
+package graph
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+// minParallelFrontier is the smallest frontier worth splitting across goroutines
+const minParallelFrontier = 1024
+
+// BFSTree runs a breadth-first search from start and returns the distance
+// (in edges) and BFS tree parent of every vertex, indexed by vertex.
+// Unreached vertices have distance -1, and both they and start have parent -1.
+// Both slices are nil if start is not a vertex.
+func (c *CSR) BFSTree(start int) ([]int, []int) {
+	if !c.hasVertex(start) {
+		return nil, nil
+	}
+	parent := c.bfs(start, func(int) {}, func(int, int) {})
+	dist := make([]int, c.nVertices+1)
+	for i := range dist {
+		dist[i] = -1
+	}
+	dist[start] = 0
+	queue := []int{start}
+	for head := 0; head < len(queue); head++ {
+		v := queue[head]
+		for _, y := range c.Neighbors(v) {
+			if dist[y] == -1 && parent[y] == v {
+				dist[y] = dist[v] + 1
+				queue = append(queue, y)
+			}
+		}
+	}
+	return dist, parent
+}
+
+// ParallelBFSTree returns the same distances and parents as BFSTree but
+// expands each level of the search across the given number of goroutines.
+//
+// Each level runs in two passes over the frontier. The first claims every
+// newly reached vertex for the earliest frontier vertex adjacent to it, which
+// is the vertex a sequential search would dequeue first. The second lets each
+// frontier vertex collect its claimed vertices in adjacency order, so the next
+// frontier is ordered exactly like the sequential queue.
+//
+// The search is top-down only: every level scans the edges out of the
+// frontier. There is no bottom-up (direction-optimizing) step that scans the
+// unvisited vertices instead, since that would pick different, though equally
+// short, parents than the sequential search. Both slices are nil if start is
+// not a vertex.
+func (c *CSR) ParallelBFSTree(start, workers int) ([]int, []int) {
+	if !c.hasVertex(start) {
+		return nil, nil
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	n := c.nVertices
+	dist := make([]int, n+1)
+	parent := make([]int, n+1)
+	owner := make([]int64, n+1) // frontier position that claimed each vertex
+	for i := range dist {
+		dist[i] = -1
+		parent[i] = -1
+		owner[i] = math.MaxInt64
+	}
+	dist[start] = 0
+	frontier := []int{start}
+
+	for level := 0; len(frontier) > 0; level++ {
+		w := workers
+		if len(frontier) < minParallelFrontier {
+			w = 1
+		}
+		chunk := (len(frontier) + 4*w - 1) / (4 * w)
+		nChunks := (len(frontier) + chunk - 1) / chunk
+
+		// Pass 1: claim unvisited neighbours for the earliest frontier vertex
+		forEachChunk(nChunks, w, func(k int) {
+			for i := k * chunk; i < len(frontier) && i < (k+1)*chunk; i++ {
+				for _, y := range c.Neighbors(frontier[i]) {
+					if dist[y] == -1 {
+						atomicMin(&owner[y], int64(i))
+					}
+				}
+			}
+		})
+
+		// Pass 2: each frontier vertex collects the vertices it claimed
+		found := make([][]int, nChunks)
+		forEachChunk(nChunks, w, func(k int) {
+			for i := k * chunk; i < len(frontier) && i < (k+1)*chunk; i++ {
+				v := frontier[i]
+				for _, y := range c.Neighbors(v) {
+					if owner[y] == int64(i) && dist[y] == -1 {
+						dist[y] = level + 1
+						parent[y] = v
+						found[k] = append(found[k], y)
+					}
+				}
+			}
+		})
+
+		frontier = frontier[:0:0]
+		for _, f := range found {
+			frontier = append(frontier, f...)
+		}
+	}
+	return dist, parent
+}
+
+// forEachChunk calls fn for every chunk index in [0, nChunks) using up to
+// workers goroutines, returning once all chunks are done
+func forEachChunk(nChunks, workers int, fn func(int)) {
+	if workers == 1 {
+		for k := 0; k < nChunks; k++ {
+			fn(k)
+		}
+		return
+	}
+	var next int64 = -1
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := int(atomic.AddInt64(&next, 1)); k < nChunks; k = int(atomic.AddInt64(&next, 1)) {
+				fn(k)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// atomicMin lowers *addr to val if val is smaller
+func atomicMin(addr *int64, val int64) {
+	for {
+		old := atomic.LoadInt64(addr)
+		if val >= old || atomic.CompareAndSwapInt64(addr, old, val) {
+			return
+		}
+	}
+}
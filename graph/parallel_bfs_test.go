@@ -0,0 +1,70 @@
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestBFSTree(t *testing.T) {
+	g := initGraph(true)
+	dist, parent := g.Freeze().BFSTree(1)
+	if !reflect.DeepEqual(dist, []int{-1, 0, 1, 2, 3, 4, 1, -1, -1, -1, -1}) {
+		t.Error("Incorrect BFS distances")
+	}
+	g.FindPath(1, 1)
+	for v := 1; v <= g.NumVertices(); v++ {
+		if parent[v] != g.Parent[v] {
+			t.Error("BFS tree parents differ from Graph search")
+		}
+	}
+}
+
+func TestParallelBFSTree(t *testing.T) {
+	for _, directed := range []bool{true, false} {
+		g := ErdosRenyi(5000, 0.002, rand.NewSource(7))
+		if directed {
+			g = NewGraph(true)
+			r := rand.New(rand.NewSource(7))
+			for i := 0; i < 40000; i++ {
+				g.InsertEdge(r.Intn(5000)+1, r.Intn(5000)+1, true)
+			}
+		}
+		c := g.Freeze()
+		dist, parent := c.BFSTree(1)
+		for _, workers := range []int{1, 4} {
+			pDist, pParent := c.ParallelBFSTree(1, workers)
+			if !reflect.DeepEqual(dist, pDist) || !reflect.DeepEqual(parent, pParent) {
+				t.Errorf("Parallel BFS with %v workers differs from sequential BFS", workers)
+			}
+		}
+	}
+}
+
+func TestBFSTreeVertexRange(t *testing.T) {
+	c := initGraph(true).Freeze()
+	for _, v := range []int{0, -1, 11} {
+		if dist, parent := c.BFSTree(v); dist != nil || parent != nil {
+			t.Errorf("Vertex %v is not in the graph and should have no BFS tree", v)
+		}
+		if dist, parent := c.ParallelBFSTree(v, 4); dist != nil || parent != nil {
+			t.Errorf("Vertex %v is not in the graph and should have no parallel BFS tree", v)
+		}
+	}
+}
+
+func BenchmarkBFSTree(b *testing.B) {
+	c := ErdosRenyi(20000, 0.001, rand.NewSource(1)).Freeze()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.BFSTree(1)
+	}
+}
+
+func BenchmarkParallelBFSTree(b *testing.B) {
+	c := ErdosRenyi(20000, 0.001, rand.NewSource(1)).Freeze()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.ParallelBFSTree(1, 4)
+	}
+}
@@ -0,0 +1,488 @@
+package graph
+
+import (
+	"errors"
+	"sort"
+)
+
+// IsPlanar reports whether the graph can be drawn in the plane without any
+// edges crossing. Edge direction, parallel edges and self-loops are ignored.
+func (g *Graph) IsPlanar() bool {
+	_, ok := lrPlanarity(g.simpleNeighbors())
+	return ok
+}
+
+// PlanarEmbedding returns a combinatorial embedding of a planar graph: the
+// neighbours of every vertex in clockwise order around it in some crossing
+// free drawing. It uses the left-right planarity test, which runs in linear
+// time, on the underlying simple undirected graph.
+func (g *Graph) PlanarEmbedding() (map[int][]int, error) {
+	rotation, ok := lrPlanarity(g.simpleNeighbors())
+	if !ok {
+		return nil, errors.New("Graph is not planar")
+	}
+	embedding := make(map[int][]int)
+	for v := 1; v <= g.nVertices; v++ {
+		embedding[v] = rotation[v]
+	}
+	return embedding, nil
+}
+
+// KuratowskiSubgraph returns the edges of a subgraph of a non-planar graph
+// that is a subdivision of K5 or K3,3, proving the graph is not planar. Edges
+// are dropped one at a time as long as the rest stays non-planar, so it takes
+// one linear planarity test per edge.
+func (g *Graph) KuratowskiSubgraph() ([]Edge, error) {
+	nbrs := g.simpleNeighbors()
+	if _, ok := lrPlanarity(nbrs); ok {
+		return nil, errors.New("Graph is planar")
+	}
+	edges := []Edge{}
+	for x, list := range nbrs {
+		for _, y := range list {
+			if x < y {
+				edges = append(edges, Edge{X: x, Y: y})
+			}
+		}
+	}
+	keep := make([]bool, len(edges))
+	for i := range keep {
+		keep[i] = true
+	}
+	for i := range edges {
+		keep[i] = false
+		if _, ok := lrPlanarity(keptNeighbors(len(nbrs)-1, edges, keep)); ok {
+			keep[i] = true // Needed to stay non-planar
+		}
+	}
+	witness := []Edge{}
+	for i, e := range edges {
+		if keep[i] {
+			witness = append(witness, e)
+		}
+	}
+	return witness, nil
+}
+
+// keptNeighbors builds sorted neighbour lists from the kept edges
+func keptNeighbors(n int, edges []Edge, keep []bool) [][]int {
+	nbrs := make([][]int, n+1)
+	for i, e := range edges {
+		if keep[i] {
+			nbrs[e.X] = append(nbrs[e.X], e.Y)
+			nbrs[e.Y] = append(nbrs[e.Y], e.X)
+		}
+	}
+	for _, list := range nbrs {
+		sort.Ints(list)
+	}
+	return nbrs
+}
+
+// interval is a range of return edges, from the one with the lowest lowpoint
+// (low) to the one with the highest (high), linked through lrState.ref.
+// -1 marks an empty end.
+type interval struct {
+	low, high int
+}
+
+func (i interval) empty() bool {
+	return i.low == -1 && i.high == -1
+}
+
+var emptyInterval = interval{-1, -1}
+
+// conflictPair holds two intervals of return edges that must be embedded on
+// opposite sides
+type conflictPair struct {
+	left, right interval
+}
+
+func (p *conflictPair) swap() {
+	p.left, p.right = p.right, p.left
+}
+
+// lrState holds the data of the left-right planarity test. The simple graph
+// is oriented by a DFS, and every oriented edge (arc) is identified by an
+// index into the per arc slices.
+type lrState struct {
+	adj        [][]int
+	height     []int // DFS depth of each vertex, -1 when unvisited
+	parentEdge []int // Arc entering each vertex in the DFS tree, -1 for roots
+	roots      []int
+	out        [][]int // Arcs leaving each vertex
+
+	from, to      []int
+	lowpt, lowpt2 []int // Lowest and second lowest return heights of each arc
+	nestingDepth  []int
+	ref           []int // Arc whose side this arc's side is relative to, -1 if none
+	side          []int
+	lowptEdge     []int
+	stackBottom   []*conflictPair
+
+	stack             []*conflictPair
+	leftRef, rightRef []int
+	offset            []int // Index of the first half edge of each vertex
+	cw, ccw           []int // Clockwise and counterclockwise neighbour, by half edge
+	first             []int
+}
+
+// halfEdge returns the index of the half edge from v to its neighbour w
+func (s *lrState) halfEdge(v, w int) int {
+	return s.offset[v] + sort.SearchInts(s.adj[v], w)
+}
+
+// lrPlanarity runs the left-right planarity test on a simple undirected graph
+// given by sorted neighbour lists of the vertices 1..n. It returns the
+// clockwise neighbours of every vertex when the graph is planar.
+func lrPlanarity(adj [][]int) ([][]int, bool) {
+	n := len(adj) - 1
+	m := 0
+	for _, list := range adj {
+		m += len(list)
+	}
+	m /= 2
+	if n > 2 && m > 3*n-6 {
+		return nil, false
+	}
+	s := &lrState{adj: adj}
+	s.height = make([]int, n+1)
+	s.parentEdge = make([]int, n+1)
+	s.out = make([][]int, n+1)
+	for v := range s.height {
+		s.height[v] = -1
+		s.parentEdge[v] = -1
+	}
+	for v := 1; v <= n; v++ {
+		if s.height[v] == -1 {
+			s.height[v] = 0
+			s.roots = append(s.roots, v)
+			s.orient(v)
+		}
+	}
+
+	s.ref = make([]int, len(s.from))
+	s.side = make([]int, len(s.from))
+	s.lowptEdge = make([]int, len(s.from))
+	s.stackBottom = make([]*conflictPair, len(s.from))
+	for a := range s.ref {
+		s.ref[a] = -1
+		s.side[a] = 1
+	}
+	for v := 1; v <= n; v++ {
+		s.sortArcs(v)
+	}
+	for _, root := range s.roots {
+		if !s.test(root) {
+			return nil, false
+		}
+	}
+
+	for a := range s.nestingDepth {
+		s.nestingDepth[a] *= s.sign(a)
+	}
+	s.offset = make([]int, n+2)
+	for v := 1; v <= n; v++ {
+		s.offset[v+1] = s.offset[v] + len(adj[v])
+	}
+	s.cw = make([]int, 2*m)
+	s.ccw = make([]int, 2*m)
+	s.first = make([]int, n+1)
+	s.leftRef = make([]int, n+1)
+	s.rightRef = make([]int, n+1)
+	for v := 1; v <= n; v++ {
+		s.sortArcs(v)
+		previous := 0
+		for _, a := range s.out[v] {
+			s.addHalfEdgeCW(v, s.to[a], previous)
+			previous = s.to[a]
+		}
+	}
+	for _, root := range s.roots {
+		s.embed(root)
+	}
+
+	rotation := make([][]int, n+1)
+	for v := 1; v <= n; v++ {
+		rotation[v] = []int{}
+		if s.first[v] == 0 {
+			continue
+		}
+		for w := s.first[v]; ; {
+			rotation[v] = append(rotation[v], w)
+			if w = s.cw[s.halfEdge(v, w)]; w == s.first[v] {
+				break
+			}
+		}
+	}
+	return rotation, true
+}
+
+// sortArcs orders the arcs leaving v by nesting depth
+func (s *lrState) sortArcs(v int) {
+	sort.SliceStable(s.out[v], func(i, j int) bool {
+		return s.nestingDepth[s.out[v][i]] < s.nestingDepth[s.out[v][j]]
+	})
+}
+
+// orient directs every edge away from the DFS root, computing the lowpoints
+// and nesting depth of each arc
+func (s *lrState) orient(v int) {
+	e := s.parentEdge[v]
+	for _, w := range s.adj[v] {
+		// Skip edges oriented from the other end: the tree edge from the
+		// parent and back edges from descendants visited earlier
+		if e != -1 && s.from[e] == w || s.height[w] > s.height[v] {
+			continue
+		}
+		vw := len(s.from)
+		s.from = append(s.from, v)
+		s.to = append(s.to, w)
+		s.lowpt = append(s.lowpt, s.height[v])
+		s.lowpt2 = append(s.lowpt2, s.height[v])
+		s.nestingDepth = append(s.nestingDepth, 0)
+		s.out[v] = append(s.out[v], vw)
+		if s.height[w] == -1 { // Tree edge
+			s.parentEdge[w] = vw
+			s.height[w] = s.height[v] + 1
+			s.orient(w)
+		} else { // Back edge
+			s.lowpt[vw] = s.height[w]
+		}
+
+		s.nestingDepth[vw] = 2 * s.lowpt[vw]
+		if s.lowpt2[vw] < s.height[v] { // Chordal
+			s.nestingDepth[vw]++
+		}
+
+		if e != -1 { // Update the lowpoints of the parent edge
+			switch {
+			case s.lowpt[vw] < s.lowpt[e]:
+				s.lowpt2[e] = min(s.lowpt[e], s.lowpt2[vw])
+				s.lowpt[e] = s.lowpt[vw]
+			case s.lowpt[vw] > s.lowpt[e]:
+				s.lowpt2[e] = min(s.lowpt2[e], s.lowpt[vw])
+			default:
+				s.lowpt2[e] = min(s.lowpt2[e], s.lowpt2[vw])
+			}
+		}
+	}
+}
+
+func (s *lrState) top() *conflictPair {
+	if len(s.stack) == 0 {
+		return nil
+	}
+	return s.stack[len(s.stack)-1]
+}
+
+func (s *lrState) pop() *conflictPair {
+	p := s.stack[len(s.stack)-1]
+	s.stack = s.stack[:len(s.stack)-1]
+	return p
+}
+
+// conflicting reports whether the interval has a return edge above the
+// lowpoint of arc b
+func (s *lrState) conflicting(i interval, b int) bool {
+	return !i.empty() && s.lowpt[i.high] > s.lowpt[b]
+}
+
+// lowest returns the lowest return height in a conflict pair
+func (s *lrState) lowest(p *conflictPair) int {
+	if p.left.empty() {
+		return s.lowpt[p.right.low]
+	}
+	if p.right.empty() {
+		return s.lowpt[p.left.low]
+	}
+	return min(s.lowpt[p.left.low], s.lowpt[p.right.low])
+}
+
+// test checks the constraints of the subtree rooted at v, returning false
+// when they cannot be satisfied
+func (s *lrState) test(v int) bool {
+	e := s.parentEdge[v]
+	for i, ei := range s.out[v] {
+		w := s.to[ei]
+		s.stackBottom[ei] = s.top()
+		if ei == s.parentEdge[w] { // Tree edge
+			if !s.test(w) {
+				return false
+			}
+		} else { // Back edge
+			s.lowptEdge[ei] = ei
+			s.stack = append(s.stack, &conflictPair{left: emptyInterval, right: interval{ei, ei}})
+		}
+
+		// Integrate the new return edges
+		if s.lowpt[ei] < s.height[v] {
+			if i == 0 {
+				s.lowptEdge[e] = s.lowptEdge[ei]
+			} else if !s.addConstraints(ei, e) {
+				return false
+			}
+		}
+	}
+	if e != -1 {
+		s.removeBackEdges(e)
+	}
+	return true
+}
+
+// addConstraints merges the return edges of arc ei into conflict pairs with
+// those of its earlier siblings
+func (s *lrState) addConstraints(ei, e int) bool {
+	p := &conflictPair{left: emptyInterval, right: emptyInterval}
+	// Merge the return edges of ei into p.right
+	for {
+		q := s.pop()
+		if !q.left.empty() {
+			q.swap()
+		}
+		if !q.left.empty() {
+			return false
+		}
+		if s.lowpt[q.right.low] > s.lowpt[e] { // Merge intervals
+			if p.right.empty() {
+				p.right = q.right
+			} else {
+				s.ref[p.right.low] = q.right.high
+			}
+			p.right.low = q.right.low
+		} else { // Align
+			s.ref[q.right.low] = s.lowptEdge[e]
+		}
+		if s.top() == s.stackBottom[ei] {
+			break
+		}
+	}
+	// Merge the conflicting return edges of earlier siblings into p.left
+	for s.top() != nil && (s.conflicting(s.top().left, ei) || s.conflicting(s.top().right, ei)) {
+		q := s.pop()
+		if s.conflicting(q.right, ei) {
+			q.swap()
+		}
+		if s.conflicting(q.right, ei) {
+			return false
+		}
+		// Merge the interval below lowpt(ei) into p.right
+		s.ref[p.right.low] = q.right.high
+		if q.right.low != -1 {
+			p.right.low = q.right.low
+		}
+		if p.left.empty() {
+			p.left = q.left
+		} else {
+			s.ref[p.left.low] = q.left.high
+		}
+		p.left.low = q.left.low
+	}
+	if !p.left.empty() || !p.right.empty() {
+		s.stack = append(s.stack, p)
+	}
+	return true
+}
+
+// removeBackEdges drops the return edges ending at the tail of arc e, once
+// its subtree is done, and records which side e goes on
+func (s *lrState) removeBackEdges(e int) {
+	u := s.from[e]
+	// Drop entire conflict pairs
+	for len(s.stack) > 0 && s.lowest(s.top()) == s.height[u] {
+		p := s.pop()
+		if p.left.low != -1 {
+			s.side[p.left.low] = -1
+		}
+	}
+	if len(s.stack) > 0 { // One more conflict pair to consider
+		p := s.pop()
+		// Trim the left interval
+		for p.left.high != -1 && s.to[p.left.high] == u {
+			p.left.high = s.ref[p.left.high]
+		}
+		if p.left.high == -1 && p.left.low != -1 { // Just emptied
+			s.ref[p.left.low] = p.right.low
+			s.side[p.left.low] = -1
+			p.left.low = -1
+		}
+		// Trim the right interval
+		for p.right.high != -1 && s.to[p.right.high] == u {
+			p.right.high = s.ref[p.right.high]
+		}
+		if p.right.high == -1 && p.right.low != -1 { // Just emptied
+			s.ref[p.right.low] = p.left.low
+			s.side[p.right.low] = -1
+			p.right.low = -1
+		}
+		s.stack = append(s.stack, p)
+	}
+	// The side of e is the side of a highest return edge
+	if s.lowpt[e] < s.height[u] && len(s.stack) > 0 {
+		hl, hr := s.top().left.high, s.top().right.high
+		if hl != -1 && (hr == -1 || s.lowpt[hl] > s.lowpt[hr]) {
+			s.ref[e] = hl
+		} else {
+			s.ref[e] = hr
+		}
+	}
+}
+
+// sign resolves the side of arc e relative to the chain of arcs it refers to
+func (s *lrState) sign(e int) int {
+	if s.ref[e] != -1 {
+		s.side[e] *= s.sign(s.ref[e])
+		s.ref[e] = -1
+	}
+	return s.side[e]
+}
+
+// embed adds the half edges pointing back towards the root to the rotations
+func (s *lrState) embed(v int) {
+	for _, ei := range s.out[v] {
+		w := s.to[ei]
+		if ei == s.parentEdge[w] { // Tree edge
+			s.addHalfEdgeFirst(w, v)
+			s.leftRef[v] = w
+			s.rightRef[v] = w
+			s.embed(w)
+		} else if s.side[ei] == 1 { // Back edge on the right
+			s.addHalfEdgeCW(w, v, s.rightRef[w])
+		} else { // Back edge on the left
+			s.addHalfEdgeCCW(w, v, s.leftRef[w])
+			s.leftRef[w] = v
+		}
+	}
+}
+
+// addHalfEdgeCW places w clockwise after reference around v, or as the only
+// neighbour of v if reference is 0
+func (s *lrState) addHalfEdgeCW(v, w, reference int) {
+	vw := s.halfEdge(v, w)
+	if reference == 0 {
+		s.cw[vw], s.ccw[vw] = w, w
+		s.first[v] = w
+		return
+	}
+	vr := s.halfEdge(v, reference)
+	next := s.cw[vr]
+	s.cw[vr], s.ccw[vw] = w, reference
+	s.cw[vw], s.ccw[s.halfEdge(v, next)] = next, w
+}
+
+// addHalfEdgeCCW places w counterclockwise before reference around v
+func (s *lrState) addHalfEdgeCCW(v, w, reference int) {
+	if reference == 0 {
+		s.addHalfEdgeCW(v, w, 0)
+		return
+	}
+	s.addHalfEdgeCW(v, w, s.ccw[s.halfEdge(v, reference)])
+	if reference == s.first[v] {
+		s.first[v] = w
+	}
+}
+
+// addHalfEdgeFirst places w first around v
+func (s *lrState) addHalfEdgeFirst(v, w int) {
+	s.addHalfEdgeCCW(v, w, s.first[v])
+}
@@ -0,0 +1,235 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// checkEmbedding verifies that a rotation system holds every edge of the
+// simple graph once at each end and satisfies Euler's formula V - E + F = 2 on
+// each connected component, so it describes a crossing free drawing
+func checkEmbedding(t *testing.T, g *Graph, embedding map[int][]int) {
+	t.Helper()
+	nbrs := g.simpleNeighbors()
+	position := make(map[[2]int]int)
+	for v := 1; v <= g.NumVertices(); v++ {
+		if len(embedding[v]) != len(nbrs[v]) {
+			t.Fatalf("Vertex %v has neighbours %v in the embedding, expected %v", v, embedding[v], nbrs[v])
+		}
+		for i, w := range embedding[v] {
+			position[[2]int{v, w}] = i
+		}
+		for _, w := range nbrs[v] {
+			if _, ok := position[[2]int{v, w}]; !ok {
+				t.Fatalf("Edge %v - %v is missing from the embedding", v, w)
+			}
+		}
+	}
+
+	component := make(map[int]int)
+	for id, members := range g.ConnectedComponents() {
+		for _, v := range members {
+			component[v] = id
+		}
+	}
+	vertices, halfEdges, faces := make(map[int]int), make(map[int]int), make(map[int]int)
+	seen := make(map[[2]int]bool)
+	for v := 1; v <= g.NumVertices(); v++ {
+		c := component[v]
+		vertices[c]++
+		halfEdges[c] += len(nbrs[v])
+		for _, w := range embedding[v] {
+			if seen[[2]int{v, w}] {
+				continue
+			}
+			// Walk the face to the right of the half edge v -> w
+			for prev, cur := v, w; !seen[[2]int{prev, cur}]; {
+				seen[[2]int{prev, cur}] = true
+				rot := embedding[cur]
+				prev, cur = cur, rot[(position[[2]int{cur, prev}]+1)%len(rot)]
+			}
+			faces[c]++
+		}
+	}
+	for c := range vertices {
+		if halfEdges[c] > 0 && vertices[c]-halfEdges[c]/2+faces[c] != 2 {
+			t.Fatalf("Component %v has V - E + F = %v, the embedding is not planar", c, vertices[c]-halfEdges[c]/2+faces[c])
+		}
+	}
+}
+
+// checkKuratowski verifies that the edges are a subgraph of g forming a
+// subdivision of K5 or K3,3
+func checkKuratowski(t *testing.T, g *Graph, witness []Edge) {
+	t.Helper()
+	adj := make(map[int][]int)
+	nbrs := g.undirectedNeighbors()
+	for _, e := range witness {
+		if !nbrs[e.X][e.Y] {
+			t.Fatalf("Witness edge %v - %v is not in the graph", e.X, e.Y)
+		}
+		adj[e.X] = append(adj[e.X], e.Y)
+		adj[e.Y] = append(adj[e.Y], e.X)
+	}
+	branch := []int{}
+	for v, list := range adj {
+		switch {
+		case len(list) > 2:
+			branch = append(branch, v)
+		case len(list) < 2:
+			t.Fatalf("Witness vertex %v has degree %v", v, len(list))
+		}
+	}
+	// Follow each path of degree two vertices between branch vertices
+	joined := make(map[[2]int]int)
+	for _, b := range branch {
+		for _, next := range adj[b] {
+			prev, cur := b, next
+			for len(adj[cur]) == 2 {
+				if adj[cur][0] == prev {
+					prev, cur = cur, adj[cur][1]
+				} else {
+					prev, cur = cur, adj[cur][0]
+				}
+			}
+			joined[[2]int{b, cur}]++
+		}
+	}
+	for pair, count := range joined {
+		if count != 1 || pair[0] == pair[1] {
+			t.Fatalf("Branch vertices %v are joined by %v paths", pair, count)
+		}
+	}
+	switch len(branch) {
+	case 5:
+		if len(joined) != 20 {
+			t.Fatalf("Witness with 5 branch vertices is not a K5 subdivision")
+		}
+	case 6:
+		// Bipartite with parts of three: colour through the paths
+		side := map[int]int{branch[0]: 1}
+		for changed := true; changed; {
+			changed = false
+			for pair := range joined {
+				if side[pair[0]] != 0 && side[pair[1]] == 0 {
+					side[pair[1]] = -side[pair[0]]
+					changed = true
+				}
+			}
+		}
+		for pair := range joined {
+			if side[pair[0]] == side[pair[1]] {
+				t.Fatalf("Witness with 6 branch vertices is not a K3,3 subdivision")
+			}
+		}
+		if len(joined) != 18 {
+			t.Fatalf("Witness with 6 branch vertices is not a K3,3 subdivision")
+		}
+	default:
+		t.Fatalf("Witness has %v branch vertices", len(branch))
+	}
+}
+
+func TestPlanarGraphs(t *testing.T) {
+	wheel := CycleGraph(8)
+	for v := 1; v <= 8; v++ {
+		wheel.InsertEdge(9, v, false)
+	}
+	triangulated := GridGraph(6, 7)
+	for r := 0; r < 5; r++ {
+		for c := 1; c < 7; c++ {
+			triangulated.InsertEdge(r*7+c, (r+1)*7+c+1, false)
+		}
+	}
+	multi := initGraph(true)
+	multi.InsertEdge(3, 3, true)
+	multi.InsertEdge(2, 1, true)
+	for name, g := range map[string]*Graph{
+		"graph1": initGraph(false), "multigraph": multi, "K4": CompleteGraph(4),
+		"K2,7": CompleteBipartiteGraph(2, 7), "wheel": wheel, "grid": GridGraph(10, 12),
+		"triangulated grid": triangulated, "tree": RandomTree(200, rand.NewSource(2)),
+		"empty": NewGraph(false), "star": StarGraph(30),
+	} {
+		if !g.IsPlanar() {
+			t.Errorf("%v should be planar", name)
+			continue
+		}
+		embedding, err := g.PlanarEmbedding()
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkEmbedding(t, g, embedding)
+		if _, err := g.KuratowskiSubgraph(); err == nil {
+			t.Errorf("Found a Kuratowski subgraph in planar %v", name)
+		}
+	}
+}
+
+func TestNonPlanarGraphs(t *testing.T) {
+	petersen := CycleGraph(5)
+	for v := 1; v <= 5; v++ {
+		petersen.InsertEdge(v, v+5, false)
+		petersen.InsertEdge(v+5, (v+1)%5+6, false)
+	}
+	subdivided := NewGraph(false)
+	for _, e := range CompleteGraph(5).EdgeList() {
+		mid := 5 + subdivided.NumEdges()/2 + 1
+		subdivided.InsertEdge(e.X, mid, false)
+		subdivided.InsertEdge(mid, e.Y, false)
+	}
+	for name, g := range map[string]*Graph{
+		"K5": CompleteGraph(5), "K3,3": CompleteBipartiteGraph(3, 3), "Petersen": petersen,
+		"subdivided K5": subdivided, "K8": CompleteGraph(8), "grid with K3,3": mustUnion(t, GridGraph(4, 4), CompleteBipartiteGraph(3, 3)),
+	} {
+		if g.IsPlanar() {
+			t.Errorf("%v should not be planar", name)
+			continue
+		}
+		if _, err := g.PlanarEmbedding(); err == nil {
+			t.Errorf("Embedded non-planar %v", name)
+		}
+		witness, err := g.KuratowskiSubgraph()
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkKuratowski(t, g, witness)
+	}
+}
+
+// mustUnion returns the union of two graphs, failing the test on an error
+func mustUnion(t *testing.T, a, b *Graph) *Graph {
+	t.Helper()
+	h, err := a.Union(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func TestRandomPlanarity(t *testing.T) {
+	src := rand.NewSource(7)
+	planar := 0
+	for i := 0; i < 300; i++ {
+		n := 5 + i%12
+		g, _ := ErdosRenyiM(n, min(n+i%(2*n), n*(n-1)/2), src)
+		if g.IsPlanar() {
+			planar++
+			embedding, _ := g.PlanarEmbedding()
+			checkEmbedding(t, g, embedding)
+		} else {
+			witness, _ := g.KuratowskiSubgraph()
+			checkKuratowski(t, g, witness)
+		}
+	}
+	if planar < 50 || planar > 250 {
+		t.Errorf("Expected a mix of planar and non-planar graphs, got %v planar", planar)
+	}
+}
+
+func BenchmarkPlanarity(b *testing.B) {
+	g := GridGraph(300, 300)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.PlanarEmbedding()
+	}
+}
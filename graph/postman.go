@@ -0,0 +1,121 @@
+package graph
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+)
+
+// A PostmanRoute is a closed walk that uses every edge of a graph at least
+// once. Vertices start and end at the same vertex, and Edges holds the id of
+// the edge taken between each pair of consecutive vertices, so edges walked
+// more than once appear more than once.
+type PostmanRoute struct {
+	Vertices []int
+	Edges    []int
+	Cost     int
+}
+
+// ChinesePostman finds a shortest closed walk from start that covers every
+// edge of an undirected graph with non-negative weights. When some vertices
+// have odd degree, they are paired up by a minimum weight perfect matching of
+// their shortest path distances and the edges along each pair's shortest path
+// are walked twice, which makes an Eulerian circuit possible. The matching
+// is found with the blossom algorithm in O(k^3) time for k odd vertices.
+func (g *Graph) ChinesePostman(start int) (*PostmanRoute, error) {
+	if g.Directed {
+		return nil, errors.New("Chinese postman requires an undirected graph")
+	}
+	if start < 1 || start > g.nVertices {
+		return nil, errors.New("Start vertex is not in the graph")
+	}
+	edges := g.EdgeList()
+	if len(edges) == 0 {
+		return &PostmanRoute{Vertices: []int{start}, Edges: []int{}}, nil
+	}
+	for _, e := range edges {
+		if e.Weight < 0 {
+			return nil, errors.New("Chinese postman requires non-negative weights")
+		}
+	}
+	reach := g.shortestPathTree(start)
+	for _, e := range edges {
+		if reach.dist[e.X] == math.MaxInt {
+			return nil, errors.New("Edges are not all reachable from the start vertex")
+		}
+	}
+
+	odd := []int{}
+	trees := make(map[int]*pathTree)
+	for v := 1; v <= g.nVertices; v++ {
+		if g.Degree[v]%2 == 1 {
+			odd = append(odd, v)
+			trees[v] = g.shortestPathTree(v)
+		}
+	}
+	pairs := minWeightPerfectMatching(odd, func(a, b int) int { return trees[a].dist[b] })
+
+	// Walk every edge once, plus the shortest paths between matched vertices
+	ends := make([][2]int, 0, len(edges))
+	ids := make([]int, 0, len(edges))
+	weight := make(map[int]int)
+	for _, e := range edges {
+		ends = append(ends, [2]int{e.X, e.Y})
+		ids = append(ids, e.ID)
+		weight[e.ID] = e.Weight
+	}
+	for _, pair := range pairs {
+		tree := trees[pair[0]]
+		for v := pair[1]; v != pair[0]; v = tree.parent[v] {
+			ends = append(ends, [2]int{tree.parent[v], v})
+			ids = append(ids, tree.edge[v])
+		}
+	}
+
+	circuit, walked := eulerianCircuit(ends, start)
+	route := &PostmanRoute{Vertices: circuit, Edges: make([]int, len(walked))}
+	for i, index := range walked {
+		route.Edges[i] = ids[index]
+		route.Cost += weight[ids[index]]
+	}
+	return route, nil
+}
+
+// pathTree holds the shortest paths from a single source: the distance to
+// each vertex (math.MaxInt when unreachable) and the parent vertex and edge id
+// through which it is reached
+type pathTree struct {
+	dist   []int
+	parent []int
+	edge   []int
+}
+
+// shortestPathTree runs Dijkstra's algorithm from source, taking the lightest
+// of any parallel edges. Weights must not be negative.
+func (g *Graph) shortestPathTree(source int) *pathTree {
+	n := g.nVertices
+	t := &pathTree{dist: make([]int, n+1), parent: make([]int, n+1), edge: make([]int, n+1)}
+	for i := range t.dist {
+		t.dist[i] = math.MaxInt
+		t.parent[i] = -1
+	}
+	t.dist[source] = 0
+	pq := &distanceQueue{{source, 0}}
+	for pq.Len() > 0 {
+		item := heap.Pop(pq).(vertexDistance)
+		v := item.v
+		if item.d > t.dist[v] {
+			continue // stale entry
+		}
+		for edgeNode := g.Edges[v]; edgeNode != nil; edgeNode = edgeNode.Next {
+			y := edgeNode.Y
+			if d := t.dist[v] + edgeNode.Weight; d < t.dist[y] {
+				t.dist[y] = d
+				t.parent[y] = v
+				t.edge[y] = edgeNode.ID
+				heap.Push(pq, vertexDistance{y, d})
+			}
+		}
+	}
+	return t
+}
@@ -0,0 +1,179 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// checkRoute verifies that the route is a closed walk from start along real
+// edges that uses every edge of g and costs what it claims
+func checkRoute(t *testing.T, g *Graph, route *PostmanRoute, start int) {
+	t.Helper()
+	if len(route.Vertices) != len(route.Edges)+1 {
+		t.Fatalf("Route has %d vertices and %d edges", len(route.Vertices), len(route.Edges))
+	}
+	if route.Vertices[0] != start || route.Vertices[len(route.Vertices)-1] != start {
+		t.Fatalf("Route %v should start and end at %d", route.Vertices, start)
+	}
+	cost := 0
+	used := make(map[int]bool)
+	for i, id := range route.Edges {
+		e, ok := g.Edge(id)
+		x, y := route.Vertices[i], route.Vertices[i+1]
+		if !ok || !(e.X == x && e.Y == y || e.X == y && e.Y == x) {
+			t.Fatalf("Step %d from %d to %d does not follow edge %d", i, x, y, id)
+		}
+		cost += e.Weight
+		used[id] = true
+	}
+	if len(used) != g.NumEdges() {
+		t.Errorf("Route uses %d of %d edges", len(used), g.NumEdges())
+	}
+	if cost != route.Cost {
+		t.Errorf("Route costs %d but reports %d", cost, route.Cost)
+	}
+}
+
+func TestChinesePostman(t *testing.T) {
+	// Every vertex of a cycle has even degree, so each edge is walked once
+	g := CycleGraph(5)
+	route, err := g.ChinesePostman(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkRoute(t, g, route, 3)
+	if len(route.Edges) != 5 {
+		t.Errorf("An Eulerian graph should need no repeated edges: %v", route.Vertices)
+	}
+
+	// A weighted path has to be walked back
+	g = NewGraph(false)
+	g.InsertWeightedEdge(1, 2, 1, false)
+	g.InsertWeightedEdge(2, 3, 2, false)
+	route, _ = g.ChinesePostman(1)
+	checkRoute(t, g, route, 1)
+	if route.Cost != 6 {
+		t.Errorf("Incorrect cost %d for a path", route.Cost)
+	}
+
+	// K4 has four odd vertices, paired up by two extra edges
+	g = CompleteGraph(4)
+	route, _ = g.ChinesePostman(1)
+	checkRoute(t, g, route, 1)
+	if len(route.Edges) != 8 {
+		t.Errorf("Incorrect route %v for K4", route.Vertices)
+	}
+
+	// The odd vertices 1 and 3 are closer through 2 and 4 than along either
+	// of the parallel edges joining them
+	g = NewGraph(false)
+	g.InsertWeightedEdge(1, 2, 1, false)
+	g.InsertWeightedEdge(2, 4, 1, false)
+	g.InsertWeightedEdge(4, 3, 1, false)
+	g.InsertWeightedEdge(1, 3, 10, false)
+	g.InsertWeightedEdge(3, 1, 10, false)
+	g.InsertWeightedEdge(3, 3, 4, false)
+	route, _ = g.ChinesePostman(2)
+	checkRoute(t, g, route, 2)
+	if route.Cost != 30 || len(route.Edges) != 9 {
+		t.Errorf("Incorrect cost %d, want 30", route.Cost)
+	}
+}
+
+func TestChinesePostmanErrors(t *testing.T) {
+	g := NewGraph(true)
+	g.InsertEdge(1, 2, true)
+	if _, err := g.ChinesePostman(1); err == nil {
+		t.Error("Directed graphs should be rejected")
+	}
+
+	g = initGraph(false)
+	if _, err := g.ChinesePostman(1); err == nil {
+		t.Error("Edges in two components cannot be covered by one walk")
+	}
+	if _, err := g.ChinesePostman(11); err == nil {
+		t.Error("Start vertex outside the graph should be rejected")
+	}
+	g = NewGraph(false)
+	g.InsertWeightedEdge(1, 2, -1, false)
+	if _, err := g.ChinesePostman(1); err == nil {
+		t.Error("Negative weights should be rejected")
+	}
+
+	g = NewGraph(false)
+	g.InsertVertex(2)
+	if route, err := g.ChinesePostman(2); err != nil || len(route.Vertices) != 1 || route.Cost != 0 {
+		t.Error("A graph without edges should give an empty walk")
+	}
+}
+
+func TestRandomChinesePostman(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	for i := 0; i < 50; i++ {
+		g := randomWeightedGraph(r.Intn(15)+2, 0.5, r.Int63())
+		if len(g.ConnectedComponents()) != 1 {
+			continue
+		}
+		route, err := g.ChinesePostman(1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkRoute(t, g, route, 1)
+		total := 0
+		for _, e := range g.EdgeList() {
+			total += e.Weight
+		}
+		if route.Cost < total {
+			t.Errorf("Route costs %d, less than the %d of its edges", route.Cost, total)
+		}
+	}
+}
+
+// bruteForcePostman returns the cost of the shortest closed walk covering
+// every edge of a connected graph. Such a walk repeats each edge at most once
+// more, so it tries every set of repeated edges that leaves all degrees even.
+func bruteForcePostman(g *Graph) int {
+	edges := g.EdgeList()
+	best := -1
+	for set := 0; set < 1<<uint(len(edges)); set++ {
+		parity := make(map[int]int)
+		cost := 0
+		for i, e := range edges {
+			parity[e.X]++
+			parity[e.Y]++
+			cost += e.Weight
+			if set&(1<<uint(i)) != 0 {
+				parity[e.X]++
+				parity[e.Y]++
+				cost += e.Weight
+			}
+		}
+		even := true
+		for _, d := range parity {
+			even = even && d%2 == 0
+		}
+		if even && (best == -1 || cost < best) {
+			best = cost
+		}
+	}
+	return best
+}
+
+func TestChinesePostmanOptimal(t *testing.T) {
+	r := rand.New(rand.NewSource(11))
+	for tested := 0; tested < 40; {
+		g := randomWeightedGraph(r.Intn(6)+3, 0.6, r.Int63())
+		if len(g.ConnectedComponents()) != 1 || g.NumEdges() > 14 {
+			continue
+		}
+		tested++
+		route, err := g.ChinesePostman(1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkRoute(t, g, route, 1)
+		if want := bruteForcePostman(g); route.Cost != want {
+			t.Errorf("Route costs %d, the shortest covering walk costs %d", route.Cost, want)
+		}
+	}
+}
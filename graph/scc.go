@@ -0,0 +1,67 @@
+package graph
+
+import "github.com/fabioberger/data-structures/stack"
+
+// StronglyConnectedComponentTraversal implements GraphProcessor in order to
+// find strongly connected components with Tarjan's algorithm on top of DFS
+type StronglyConnectedComponentTraversal struct {
+	Current     int           // Number of the last component found
+	Components  map[int][]int // Vertices of each component
+	ComponentOf map[int]int   // Component number of each vertex
+	Low         map[int]int   // Oldest vertex reachable from each vertex's subtree
+	Active      *stack.Stack  // Vertices not yet assigned to a component
+}
+
+func NewStronglyConnectedComponentTraversal() *StronglyConnectedComponentTraversal {
+	t := new(StronglyConnectedComponentTraversal)
+	t.Components = make(map[int][]int)
+	t.ComponentOf = make(map[int]int)
+	t.Low = make(map[int]int)
+	t.Active = stack.NewStack()
+	return t
+}
+
+func (t *StronglyConnectedComponentTraversal) processVertexEarly(g *Graph, v int) {
+	t.Low[v] = v
+	t.Active.Push(v)
+}
+
+func (t *StronglyConnectedComponentTraversal) processEdge(g *Graph, x int, y int) {
+	class := g.edgeClassification(x, y)
+	if class == BACK || (class == CROSS && t.ComponentOf[y] == 0) {
+		if g.EntryTime[y] < g.EntryTime[t.Low[x]] {
+			t.Low[x] = y
+		}
+	}
+}
+
+func (t *StronglyConnectedComponentTraversal) processVertexLate(g *Graph, v int) {
+	if t.Low[v] == v { // v is the root of a component, pop it off the stack
+		t.Current++
+		for {
+			y, _ := t.Active.Pop()
+			t.ComponentOf[y] = t.Current
+			t.Components[t.Current] = append(t.Components[t.Current], y)
+			if y == v {
+				break
+			}
+		}
+	}
+	if p := g.Parent[v]; p != -1 && g.EntryTime[t.Low[v]] < g.EntryTime[t.Low[p]] {
+		t.Low[p] = t.Low[v]
+	}
+}
+
+// StronglyConnectedComponents finds the strongly connected components of a
+// directed graph. Components are numbered from 1 in the order they complete,
+// which is a reverse topological order of the condensed graph.
+func (g *Graph) StronglyConnectedComponents() map[int][]int {
+	t := NewStronglyConnectedComponentTraversal()
+	g.InitSearch()
+	for i := 1; i <= g.nVertices; i++ {
+		if g.State[i] == UNDISCOVERED {
+			g.dfs(i, t)
+		}
+	}
+	return t.Components
+}
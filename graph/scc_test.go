@@ -0,0 +1,26 @@
+package graph
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	g := initGraph(true)
+	components := g.StronglyConnectedComponents()
+	if len(components) != 7 {
+		t.Fatal("Incorrect number of strongly connected components")
+	}
+	for _, component := range components {
+		if len(component) > 1 {
+			sort.Ints(component)
+			if len(component) != 4 || component[0] != 2 || component[3] != 5 {
+				t.Error("Cycle 2-3-4-5 should form one component")
+			}
+		}
+	}
+	// Components complete sinks first, so the chain 7 -> 8 -> 9 -> 10 ends with 7
+	if components[4][0] != 10 || components[7][0] != 7 {
+		t.Error("Components are not in reverse topological order")
+	}
+}
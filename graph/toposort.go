@@ -0,0 +1,56 @@
+package graph
+
+import (
+	"container/heap"
+	"errors"
+)
+
+// TopologicalSort orders the vertices of a directed acyclic graph so that
+// every edge leads from an earlier vertex to a later one. It uses Kahn's
+// algorithm and always takes the smallest available vertex, so the order is
+// the lexicographically smallest one.
+func (g *Graph) TopologicalSort() ([]int, error) {
+	if !g.Directed {
+		return nil, errors.New("Topological sort requires a directed graph")
+	}
+	inDegree := make([]int, g.nVertices+1)
+	for _, edgeNode := range g.Edges {
+		for ; edgeNode != nil; edgeNode = edgeNode.Next {
+			inDegree[edgeNode.Y]++
+		}
+	}
+	ready := &vertexQueue{}
+	for v := 1; v <= g.nVertices; v++ {
+		if inDegree[v] == 0 {
+			heap.Push(ready, v)
+		}
+	}
+	order := []int{}
+	for ready.Len() > 0 {
+		v := heap.Pop(ready).(int)
+		order = append(order, v)
+		for edgeNode := g.Edges[v]; edgeNode != nil; edgeNode = edgeNode.Next {
+			if inDegree[edgeNode.Y]--; inDegree[edgeNode.Y] == 0 {
+				heap.Push(ready, edgeNode.Y)
+			}
+		}
+	}
+	if len(order) < g.nVertices {
+		return nil, errors.New("Graph has a cycle")
+	}
+	return order, nil
+}
+
+// vertexQueue is a min-heap of vertices
+type vertexQueue []int
+
+func (q vertexQueue) Len() int            { return len(q) }
+func (q vertexQueue) Less(i, j int) bool  { return q[i] < q[j] }
+func (q vertexQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *vertexQueue) Push(x interface{}) { *q = append(*q, x.(int)) }
+func (q *vertexQueue) Pop() interface{} {
+	old := *q
+	v := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return v
+}
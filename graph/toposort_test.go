@@ -0,0 +1,29 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTopologicalSort(t *testing.T) {
+	g := NewGraph(true)
+	g.InsertEdge(5, 3, true)
+	g.InsertEdge(3, 1, true)
+	g.InsertEdge(5, 1, true)
+	g.InsertEdge(2, 4, true)
+	g.InsertVertex(6)
+	order, err := g.TopologicalSort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(order, []int{2, 4, 5, 3, 1, 6}) {
+		t.Errorf("Incorrect topological order %v", order)
+	}
+
+	if _, err := initGraph(true).TopologicalSort(); err == nil {
+		t.Error("The cycle 2-3-4-5 should prevent a topological order")
+	}
+	if _, err := initGraph(false).TopologicalSort(); err == nil {
+		t.Error("Undirected graphs should be rejected")
+	}
+}
@@ -0,0 +1,284 @@
+package graph
+
+import (
+	"errors"
+	"sort"
+)
+
+// A RootedTree is a tree given by parent links, such as the Parent map left
+// behind by a BFS or DFS traversal of a Graph
+type RootedTree struct {
+	Root     int
+	Parent   map[int]int   // Parent of each vertex (-1 for the root)
+	Children map[int][]int // Children of each vertex in ascending order
+	Depth    map[int]int   // Number of edges between each vertex and the root
+	order    []int         // Vertices in BFS order from the root
+}
+
+// NewRootedTree builds the tree hanging off root from a parent map. Vertices
+// whose parent chain does not lead to root, such as those a traversal never
+// reached, are left out.
+func NewRootedTree(root int, parent map[int]int) *RootedTree {
+	t := &RootedTree{
+		Root:     root,
+		Parent:   map[int]int{root: -1},
+		Children: make(map[int][]int),
+		Depth:    map[int]int{root: 0},
+	}
+	for v, p := range parent {
+		if v != root && p != -1 {
+			t.Children[p] = append(t.Children[p], v)
+		}
+	}
+	t.order = []int{root}
+	for head := 0; head < len(t.order); head++ {
+		v := t.order[head]
+		sort.Ints(t.Children[v])
+		for _, c := range t.Children[v] {
+			t.Parent[c] = v
+			t.Depth[c] = t.Depth[v] + 1
+			t.order = append(t.order, c)
+		}
+	}
+	for v := range t.Children {
+		if _, ok := t.Parent[v]; !ok {
+			delete(t.Children, v) // belongs to another tree
+		}
+	}
+	return t
+}
+
+// SpanningTree runs a BFS from root and returns the resulting tree
+func (g *Graph) SpanningTree(root int) *RootedTree {
+	g.InitSearch()
+	g.bfs(root, new(QuietTraversal))
+	return NewRootedTree(root, g.Parent)
+}
+
+// Contains reports whether v is part of the tree
+func (t *RootedTree) Contains(v int) bool {
+	_, ok := t.Parent[v]
+	return ok
+}
+
+// SubtreeSizes returns the number of vertices in the subtree of every vertex
+func (t *RootedTree) SubtreeSizes() map[int]int {
+	size := make(map[int]int)
+	for i := len(t.order) - 1; i >= 0; i-- {
+		v := t.order[i]
+		size[v]++
+		if p := t.Parent[v]; p != -1 {
+			size[p] += size[v]
+		}
+	}
+	return size
+}
+
+// Diameter returns a longest path between two vertices of the tree. It finds
+// the vertex farthest from the root, then the vertex farthest from that one.
+func (t *RootedTree) Diameter() []int {
+	from := t.farthest(t.Root)
+	to := t.farthest(from)
+	prev, _ := t.walkFrom(from)
+	path := []int{}
+	for v := to; v != -1; v = prev[v] {
+		path = append(path, v)
+	}
+	return path
+}
+
+// walkFrom runs a BFS over the tree as an undirected graph from start,
+// returning each vertex's previous vertex on the way back to start and its
+// distance from start
+func (t *RootedTree) walkFrom(start int) (map[int]int, map[int]int) {
+	prev := map[int]int{start: -1}
+	dist := map[int]int{start: 0}
+	queue := []int{start}
+	for head := 0; head < len(queue); head++ {
+		v := queue[head]
+		neighbors := append([]int{}, t.Children[v]...)
+		if p := t.Parent[v]; p != -1 {
+			neighbors = append(neighbors, p)
+		}
+		for _, y := range neighbors {
+			if _, seen := prev[y]; !seen {
+				prev[y] = v
+				dist[y] = dist[v] + 1
+				queue = append(queue, y)
+			}
+		}
+	}
+	return prev, dist
+}
+
+// farthest returns the vertex with the longest path from start
+func (t *RootedTree) farthest(start int) int {
+	_, dist := t.walkFrom(start)
+	best := start
+	for _, v := range t.order {
+		if dist[v] > dist[best] {
+			best = v
+		}
+	}
+	return best
+}
+
+// Centroids returns the one or two vertices whose removal leaves no component
+// with more than half of the tree's vertices
+func (t *RootedTree) Centroids() []int {
+	size := t.SubtreeSizes()
+	n := len(t.order)
+	centroids := []int{}
+	for _, v := range t.order {
+		largest := n - size[v] // the part above v
+		for _, c := range t.Children[v] {
+			if size[c] > largest {
+				largest = size[c]
+			}
+		}
+		if 2*largest <= n {
+			centroids = append(centroids, v)
+		}
+	}
+	sort.Ints(centroids)
+	return centroids
+}
+
+// An LCAFinder answers lowest common ancestor queries on a rooted tree
+type LCAFinder interface {
+	LCA(a, b int) (int, error)
+}
+
+// BinaryLifting answers LCA queries in O(log n) time after O(n log n)
+// preprocessing by storing every vertex's 2^k-th ancestors
+type BinaryLifting struct {
+	tree *RootedTree
+	up   []map[int]int // up[k][v] is the 2^k-th ancestor of v, or -1
+}
+
+// BinaryLifting preprocesses the tree for ancestor jumps
+func (t *RootedTree) BinaryLifting() *BinaryLifting {
+	bl := &BinaryLifting{tree: t, up: []map[int]int{t.Parent}}
+	for k := 1; 1<<uint(k) < len(t.order); k++ {
+		prev := bl.up[k-1]
+		level := make(map[int]int)
+		for _, v := range t.order {
+			if mid := prev[v]; mid == -1 {
+				level[v] = -1
+			} else {
+				level[v] = prev[mid]
+			}
+		}
+		bl.up = append(bl.up, level)
+	}
+	return bl
+}
+
+// Ancestor returns the ancestor k levels above v, or -1 if there is none
+func (bl *BinaryLifting) Ancestor(v, k int) int {
+	for i := 0; v != -1 && k > 0; i++ {
+		if i >= len(bl.up) {
+			return -1
+		}
+		if k&1 == 1 {
+			v = bl.up[i][v]
+		}
+		k >>= 1
+	}
+	return v
+}
+
+// LCA returns the deepest vertex that is an ancestor of both a and b
+func (bl *BinaryLifting) LCA(a, b int) (int, error) {
+	if !bl.tree.Contains(a) || !bl.tree.Contains(b) {
+		return -1, errors.New("Vertex is not in the tree")
+	}
+	if bl.tree.Depth[a] < bl.tree.Depth[b] {
+		a, b = b, a
+	}
+	a = bl.Ancestor(a, bl.tree.Depth[a]-bl.tree.Depth[b])
+	if a == b {
+		return a, nil
+	}
+	for k := len(bl.up) - 1; k >= 0; k-- {
+		if bl.up[k][a] != bl.up[k][b] {
+			a, b = bl.up[k][a], bl.up[k][b]
+		}
+	}
+	return bl.tree.Parent[a], nil
+}
+
+// EulerTourLCA answers LCA queries in O(1) time after O(n log n)
+// preprocessing with a sparse table of range minimums over an Euler tour
+type EulerTourLCA struct {
+	tree  *RootedTree
+	tour  []int       // vertices in the order a DFS enters and re-enters them
+	first map[int]int // first position of each vertex in the tour
+	table [][]int     // table[k][i] is the shallowest tour position in [i, i+2^k)
+}
+
+// EulerTour preprocesses the tree for constant time LCA queries
+func (t *RootedTree) EulerTour() *EulerTourLCA {
+	e := &EulerTourLCA{tree: t, first: make(map[int]int)}
+	type frame struct{ v, next int }
+	stack := []frame{{t.Root, 0}}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.next == 0 {
+			e.first[top.v] = len(e.tour)
+			e.tour = append(e.tour, top.v)
+		}
+		if top.next < len(t.Children[top.v]) {
+			child := t.Children[top.v][top.next]
+			top.next++
+			stack = append(stack, frame{child, 0})
+			continue
+		}
+		stack = stack[:len(stack)-1]
+		if len(stack) > 0 { // back up to the parent
+			e.tour = append(e.tour, stack[len(stack)-1].v)
+		}
+	}
+
+	shallower := func(i, j int) int {
+		if t.Depth[e.tour[i]] <= t.Depth[e.tour[j]] {
+			return i
+		}
+		return j
+	}
+	level := make([]int, len(e.tour))
+	for i := range level {
+		level[i] = i
+	}
+	e.table = [][]int{level}
+	for k := 1; 1<<uint(k) <= len(e.tour); k++ {
+		prev := e.table[k-1]
+		level := make([]int, len(e.tour)-1<<uint(k)+1)
+		for i := range level {
+			level[i] = shallower(prev[i], prev[i+1<<uint(k-1)])
+		}
+		e.table = append(e.table, level)
+	}
+	return e
+}
+
+// LCA returns the deepest vertex that is an ancestor of both a and b
+func (e *EulerTourLCA) LCA(a, b int) (int, error) {
+	i, okA := e.first[a]
+	j, okB := e.first[b]
+	if !okA || !okB {
+		return -1, errors.New("Vertex is not in the tree")
+	}
+	if i > j {
+		i, j = j, i
+	}
+	k := 0
+	for 1<<uint(k+1) <= j-i+1 {
+		k++
+	}
+	x, y := e.table[k][i], e.table[k][j-1<<uint(k)+1]
+	if e.tree.Depth[e.tour[y]] < e.tree.Depth[e.tour[x]] {
+		x = y
+	}
+	return e.tour[x], nil
+}
@@ -0,0 +1,74 @@
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// initTreeGraph builds the tree
+//
+//	    1
+//	   / \
+//	  2   3
+//	 / \   \
+//	4   5   6
+//	    |
+//	    7
+func initTreeGraph() *Graph {
+	g := NewGraph(false)
+	for _, e := range [][2]int{{1, 2}, {1, 3}, {2, 4}, {2, 5}, {3, 6}, {5, 7}} {
+		g.InsertEdge(e[0], e[1], false)
+	}
+	return g
+}
+
+func TestLCA(t *testing.T) {
+	tree := initTreeGraph().SpanningTree(1)
+	cases := [][3]int{{4, 7, 2}, {7, 6, 1}, {5, 7, 5}, {6, 6, 6}, {4, 5, 2}}
+	for _, finder := range []LCAFinder{tree.BinaryLifting(), tree.EulerTour()} {
+		for _, c := range cases {
+			if lca, err := finder.LCA(c[0], c[1]); err != nil || lca != c[2] {
+				t.Errorf("%T: incorrect LCA of %v and %v", finder, c[0], c[1])
+			}
+		}
+		if _, err := finder.LCA(1, 8); err == nil {
+			t.Errorf("%T: found LCA of a vertex outside the tree", finder)
+		}
+	}
+}
+
+func TestLCAAgreement(t *testing.T) {
+	tree := RandomTree(300, rand.NewSource(3)).SpanningTree(1)
+	lifting, euler := tree.BinaryLifting(), tree.EulerTour()
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 500; i++ {
+		a, b := r.Intn(300)+1, r.Intn(300)+1
+		x, _ := lifting.LCA(a, b)
+		y, _ := euler.LCA(a, b)
+		if x != y {
+			t.Fatalf("LCA implementations disagree on %v and %v", a, b)
+		}
+	}
+}
+
+func TestTreeQueries(t *testing.T) {
+	tree := initTreeGraph().SpanningTree(1)
+	size := tree.SubtreeSizes()
+	if size[1] != 7 || size[2] != 4 || size[3] != 2 || size[7] != 1 {
+		t.Errorf("Incorrect subtree sizes: %v", size)
+	}
+	if !reflect.DeepEqual(tree.Diameter(), []int{6, 3, 1, 2, 5, 7}) &&
+		!reflect.DeepEqual(tree.Diameter(), []int{7, 5, 2, 1, 3, 6}) {
+		t.Errorf("Incorrect diameter: %v", tree.Diameter())
+	}
+	if !reflect.DeepEqual(tree.Centroids(), []int{2}) {
+		t.Errorf("Incorrect centroids: %v", tree.Centroids())
+	}
+
+	// Vertices the traversal did not reach are not part of the tree
+	g := initGraph(false)
+	if g.SpanningTree(1).Contains(7) {
+		t.Error("Tree includes a vertex from another component")
+	}
+}
@@ -0,0 +1,192 @@
+package graph
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+// triangleChunk is the number of vertices each goroutine takes at a time
+const triangleChunk = 64
+
+// simpleNeighbors returns the sorted neighbours of every vertex in the
+// underlying simple undirected graph, without parallel edges or self-loops.
+// It is a slice based version of undirectedNeighbors for large graphs.
+func (g *Graph) simpleNeighbors() [][]int {
+	nbrs := make([][]int, g.nVertices+1)
+	for x, edgeNode := range g.Edges {
+		for ; edgeNode != nil; edgeNode = edgeNode.Next {
+			if x == edgeNode.Y {
+				continue
+			}
+			nbrs[x] = append(nbrs[x], edgeNode.Y)
+			if g.Directed {
+				nbrs[edgeNode.Y] = append(nbrs[edgeNode.Y], x)
+			}
+		}
+	}
+	for v, list := range nbrs {
+		sort.Ints(list)
+		unique := list[:0]
+		for i, y := range list {
+			if i == 0 || y != list[i-1] {
+				unique = append(unique, y)
+			}
+		}
+		nbrs[v] = unique
+	}
+	return nbrs
+}
+
+// forwardAdjacency orients every edge of the underlying simple undirected
+// graph from the endpoint of lower degree to the one of higher degree (ties
+// broken by vertex number). Each vertex keeps its out-neighbours sorted by
+// that rank, and no vertex has more than O(sqrt(m)) of them.
+func forwardAdjacency(nbrs [][]int, workers int) ([][]int, []int) {
+	n := len(nbrs) - 1
+	order := make([]int, 0, n)
+	for v := 1; v <= n; v++ {
+		order = append(order, v)
+	}
+	sort.Slice(order, func(i, j int) bool {
+		di, dj := len(nbrs[order[i]]), len(nbrs[order[j]])
+		return di < dj || di == dj && order[i] < order[j]
+	})
+	rank := make([]int, n+1)
+	for r, v := range order {
+		rank[v] = r
+	}
+	out := make([][]int, n+1)
+	forEachVertexChunk(n, workers, func(v int) {
+		for _, y := range nbrs[v] {
+			if rank[y] > rank[v] {
+				out[v] = append(out[v], y)
+			}
+		}
+		sort.Slice(out[v], func(i, j int) bool { return rank[out[v][i]] < rank[out[v][j]] })
+	})
+	return out, rank
+}
+
+// forEachVertexChunk calls fn for each of the vertices 1..n, handing them to
+// the workers in chunks of triangleChunk consecutive vertices
+func forEachVertexChunk(n, workers int, fn func(v int)) {
+	nChunks := (n + triangleChunk - 1) / triangleChunk
+	forEachChunk(nChunks, workers, func(k int) {
+		for v := k*triangleChunk + 1; v <= n && v <= (k+1)*triangleChunk; v++ {
+			fn(v)
+		}
+	})
+}
+
+// trianglesAt calls visit for every triangle whose lowest ranked vertex is u,
+// by merging the sorted out-neighbours of u with those of each out-neighbour
+func trianglesAt(u int, out [][]int, rank []int, visit func(a, b, c int)) {
+	for _, v := range out[u] {
+		i, j := 0, 0
+		for i < len(out[u]) && j < len(out[v]) {
+			a, b := out[u][i], out[v][j]
+			switch {
+			case rank[a] < rank[b]:
+				i++
+			case rank[a] > rank[b]:
+				j++
+			default:
+				visit(u, v, a)
+				i++
+				j++
+			}
+		}
+	}
+}
+
+// Triangles lists every triangle of the graph once, ignoring edge direction,
+// parallel edges and self-loops. Each triangle is sorted by vertex number.
+// The forward algorithm takes O(m^1.5) time.
+func (g *Graph) Triangles() [][3]int {
+	out, rank := forwardAdjacency(g.simpleNeighbors(), 1)
+	triangles := [][3]int{}
+	for u := 1; u <= g.nVertices; u++ {
+		trianglesAt(u, out, rank, func(a, b, c int) {
+			t := [3]int{a, b, c}
+			sort.Ints(t[:])
+			triangles = append(triangles, t)
+		})
+	}
+	return triangles
+}
+
+// TriangleCount returns the number of triangles in the graph
+func (g *Graph) TriangleCount() int {
+	total, _ := g.TriangleCounts(1)
+	return total
+}
+
+// TriangleCounts returns the number of triangles in the graph and the number
+// each vertex belongs to. With more than one worker the vertices are split
+// across that many goroutines.
+func (g *Graph) TriangleCounts(workers int) (int, map[int]int) {
+	if workers < 1 {
+		workers = 1
+	}
+	n := g.nVertices
+	out, rank := forwardAdjacency(g.simpleNeighbors(), workers)
+	counts := make([]int64, n+1)
+	var total int64
+	forEachVertexChunk(n, workers, func(u int) {
+		trianglesAt(u, out, rank, func(a, b, c int) {
+			atomic.AddInt64(&counts[a], 1)
+			atomic.AddInt64(&counts[b], 1)
+			atomic.AddInt64(&counts[c], 1)
+			atomic.AddInt64(&total, 1)
+		})
+	})
+	perVertex := make(map[int]int)
+	for v := 1; v <= n; v++ {
+		perVertex[v] = int(counts[v])
+	}
+	return int(total), perVertex
+}
+
+// LocalClustering returns the clustering coefficient of every vertex: the
+// fraction of pairs of its neighbours that are themselves adjacent. Vertices
+// with fewer than two neighbours have coefficient 0.
+func (g *Graph) LocalClustering() map[int]float64 {
+	nbrs := g.simpleNeighbors()
+	_, triangles := g.TriangleCounts(1)
+	clustering := make(map[int]float64)
+	for v := 1; v <= g.nVertices; v++ {
+		d := len(nbrs[v])
+		if d < 2 {
+			clustering[v] = 0
+			continue
+		}
+		clustering[v] = float64(2*triangles[v]) / float64(d*(d-1))
+	}
+	return clustering
+}
+
+// AverageClustering returns the mean of the local clustering coefficients
+func (g *Graph) AverageClustering() float64 {
+	if g.nVertices == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, c := range g.LocalClustering() {
+		sum += c
+	}
+	return sum / float64(g.nVertices)
+}
+
+// GlobalClustering returns the transitivity of the graph: three times the
+// number of triangles divided by the number of paths of length two
+func (g *Graph) GlobalClustering() float64 {
+	triples := 0
+	for _, list := range g.simpleNeighbors() {
+		d := len(list)
+		triples += d * (d - 1) / 2
+	}
+	if triples == 0 {
+		return 0
+	}
+	return float64(3*g.TriangleCount()) / float64(triples)
+}
@@ -0,0 +1,102 @@
+package graph
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestTriangles(t *testing.T) {
+	g := initGraph(false)
+	if tr := g.Triangles(); len(tr) != 0 {
+		t.Errorf("graph1 has no triangles, found %v", tr)
+	}
+	g.InsertEdge(2, 4, false)
+	g.InsertEdge(4, 2, true) // parallel edges are ignored
+	if tr := g.Triangles(); len(tr) != 2 || !containsTriangle(tr, [3]int{2, 3, 4}) || !containsTriangle(tr, [3]int{2, 4, 5}) {
+		t.Errorf("Incorrect triangles: %v", tr)
+	}
+	if c := CompleteGraph(6).TriangleCount(); c != 20 {
+		t.Errorf("K6 should have 20 triangles, found %v", c)
+	}
+	if c := CompleteBipartiteGraph(4, 5).TriangleCount(); c != 0 {
+		t.Errorf("Bipartite graphs have no triangles, found %v", c)
+	}
+}
+
+func containsTriangle(triangles [][3]int, t [3]int) bool {
+	for _, x := range triangles {
+		if x == t {
+			return true
+		}
+	}
+	return false
+}
+
+func TestTriangleCounts(t *testing.T) {
+	g := ErdosRenyi(300, 0.1, rand.NewSource(5))
+	total, perVertex := g.TriangleCounts(1)
+
+	// Compare with the trace of the cube of the adjacency matrix
+	walks := g.AdjacencyMatrix().CountWalks(3)
+	trace, sum := 0, 0
+	for v := 1; v <= g.NumVertices(); v++ {
+		trace += walks[v][v]
+		sum += perVertex[v]
+		if walks[v][v] != 2*perVertex[v] {
+			t.Errorf("Vertex %v is in %v triangles, expected %v", v, perVertex[v], walks[v][v]/2)
+		}
+	}
+	if total != trace/6 || sum != 3*total {
+		t.Errorf("Incorrect triangle total %v, expected %v", total, trace/6)
+	}
+
+	parallelTotal, parallelCounts := g.TriangleCounts(4)
+	if parallelTotal != total || !reflect.DeepEqual(parallelCounts, perVertex) {
+		t.Error("Parallel triangle counts differ from sequential counts")
+	}
+}
+
+func TestClustering(t *testing.T) {
+	k := CompleteGraph(5)
+	if k.GlobalClustering() != 1 || k.AverageClustering() != 1 {
+		t.Error("Complete graphs should have clustering 1")
+	}
+	if s := StarGraph(6); s.GlobalClustering() != 0 || s.AverageClustering() != 0 {
+		t.Error("Stars should have clustering 0")
+	}
+
+	// A triangle with a pendant vertex attached to 1
+	g := NewGraph(false)
+	g.InsertEdge(1, 2, false)
+	g.InsertEdge(2, 3, false)
+	g.InsertEdge(3, 1, false)
+	g.InsertEdge(1, 4, false)
+	local := g.LocalClustering()
+	if math.Abs(local[1]-1.0/3) > 1e-9 || local[2] != 1 || local[4] != 0 {
+		t.Errorf("Incorrect local clustering: %v", local)
+	}
+	if c := g.GlobalClustering(); math.Abs(c-3.0/5) > 1e-9 {
+		t.Errorf("Incorrect global clustering: %v", c)
+	}
+	if c := g.AverageClustering(); math.Abs(c-(1.0/3+2)/4) > 1e-9 {
+		t.Errorf("Incorrect average clustering: %v", c)
+	}
+}
+
+func BenchmarkTriangleCounts(b *testing.B) {
+	g, _ := BarabasiAlbert(20000, 8, rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.TriangleCounts(1)
+	}
+}
+
+func BenchmarkParallelTriangleCounts(b *testing.B) {
+	g, _ := BarabasiAlbert(20000, 8, rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.TriangleCounts(4)
+	}
+}
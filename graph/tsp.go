@@ -0,0 +1,276 @@
+package graph
+
+import (
+	"errors"
+	"math"
+)
+
+// A Tour is an ordering of vertices along with its total edge weight.
+// Closed tours return from the last vertex to the first and that edge's
+// weight is included in Cost.
+type Tour struct {
+	Vertices []int
+	Cost     int
+	Closed   bool
+}
+
+// weightMatrix returns the lightest edge weight between every ordered pair of
+// vertices, with math.MaxInt marking missing edges. The second return value
+// reports whether every pair of distinct vertices is joined by an edge.
+func (g *Graph) weightMatrix() ([][]int, bool) {
+	n := g.nVertices
+	w := make([][]int, n+1)
+	for i := range w {
+		w[i] = make([]int, n+1)
+		for j := range w[i] {
+			w[i][j] = math.MaxInt
+		}
+	}
+	for x, edgeNode := range g.Edges {
+		for ; edgeNode != nil; edgeNode = edgeNode.Next {
+			if edgeNode.Weight < w[x][edgeNode.Y] {
+				w[x][edgeNode.Y] = edgeNode.Weight
+			}
+		}
+	}
+	complete := true
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= n; j++ {
+			if i != j && w[i][j] == math.MaxInt {
+				complete = false
+			}
+		}
+	}
+	return w, complete
+}
+
+// tourCost sums the weights along the order, closing the loop if asked to
+func tourCost(w [][]int, order []int, closed bool) int {
+	cost := 0
+	for i := 0; i+1 < len(order); i++ {
+		cost += w[order[i]][order[i+1]]
+	}
+	if closed && len(order) > 1 {
+		cost += w[order[len(order)-1]][order[0]]
+	}
+	return cost
+}
+
+// NearestNeighborTour builds a closed tour over a complete weighted graph by
+// always moving to the closest unvisited vertex
+func (g *Graph) NearestNeighborTour(start int) (*Tour, error) {
+	w, complete := g.weightMatrix()
+	if !complete {
+		return nil, errors.New("Graph is not complete")
+	}
+	if start < 1 || start > g.nVertices {
+		return nil, errors.New("Start vertex is not in the graph")
+	}
+	visited := make(map[int]bool)
+	order := []int{start}
+	visited[start] = true
+	for len(order) < g.nVertices {
+		current := order[len(order)-1]
+		next := -1
+		for y := 1; y <= g.nVertices; y++ {
+			if !visited[y] && (next == -1 || w[current][y] < w[current][next]) {
+				next = y
+			}
+		}
+		visited[next] = true
+		order = append(order, next)
+	}
+	return &Tour{Vertices: order, Cost: tourCost(w, order, true), Closed: true}, nil
+}
+
+// TwoOpt improves a closed tour over a complete graph by reversing segments for
+// as long as doing so shortens it. Each candidate move is priced from the edges
+// it changes, so a pass over all moves takes O(n²) time. It returns an error
+// if the graph is not complete or the tour does not visit every vertex once.
+func (g *Graph) TwoOpt(tour *Tour) (*Tour, error) {
+	w, err := g.localSearchMatrix(tour)
+	if err != nil {
+		return nil, err
+	}
+	order := append([]int{}, tour.Vertices...)
+	cost := tourCost(w, order, true)
+	n := len(order)
+	// forward[k] and backward[k] sum the weights of the first k tour edges
+	// walked forwards and backwards, to price reversed segments of directed
+	// tours
+	forward, backward := make([]int, n), make([]int, n)
+	prefixSums := func() {
+		for k := 1; k < n; k++ {
+			forward[k] = forward[k-1] + w[order[k-1]][order[k]]
+			backward[k] = backward[k-1] + w[order[k]][order[k-1]]
+		}
+	}
+	prefixSums()
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < n-1; i++ {
+			for j := i + 2; j < n; j++ {
+				if i == 0 && j == n-1 {
+					continue // reversing everything but one vertex changes nothing
+				}
+				a, b, c, d := order[i], order[i+1], order[j], order[(j+1)%n]
+				delta := w[a][c] + w[b][d] - w[a][b] - w[c][d] +
+					backward[j] - backward[i+1] - (forward[j] - forward[i+1])
+				if delta < 0 {
+					reverse(order, i+1, j)
+					cost += delta
+					prefixSums()
+					improved = true
+				}
+			}
+		}
+	}
+	return &Tour{Vertices: order, Cost: cost, Closed: true}, nil
+}
+
+// OrOpt improves a closed tour over a complete graph by moving segments of one
+// to three consecutive vertices to a cheaper position for as long as doing so
+// shortens it. Each candidate move is priced from the edges it changes. It
+// returns the same errors as TwoOpt.
+func (g *Graph) OrOpt(tour *Tour) (*Tour, error) {
+	w, err := g.localSearchMatrix(tour)
+	if err != nil {
+		return nil, err
+	}
+	order := append([]int{}, tour.Vertices...)
+	cost := tourCost(w, order, true)
+	n := len(order)
+	for improved := true; improved; {
+		improved = false
+		for length := 1; length <= 3 && length < n-1; length++ {
+			for i := 0; i+length <= n && !improved; i++ {
+				first, last := order[i], order[i+length-1]
+				prev, next := order[(i+n-1)%n], order[(i+length)%n]
+				removed := w[prev][next] - w[prev][first] - w[last][next]
+				// rest is the tour without the segment
+				restLen := n - length
+				rest := func(k int) int {
+					k = (k + restLen) % restLen
+					if k < i {
+						return order[k]
+					}
+					return order[k+length]
+				}
+				for k := 0; k <= restLen && !improved; k++ {
+					if k == i {
+						continue // same position
+					}
+					p, q := rest(k-1), rest(k)
+					if delta := removed + w[p][first] + w[last][q] - w[p][q]; delta < 0 {
+						segment := append([]int{}, order[i:i+length]...)
+						others := append(append([]int{}, order[:i]...), order[i+length:]...)
+						order = append(append(append([]int{}, others[:k]...), segment...), others[k:]...)
+						cost += delta
+						improved = true
+					}
+				}
+			}
+		}
+	}
+	return &Tour{Vertices: order, Cost: cost, Closed: true}, nil
+}
+
+// localSearchMatrix returns the weight matrix for improving the tour, checking
+// that the graph is complete and the tour visits each vertex exactly once
+func (g *Graph) localSearchMatrix(tour *Tour) ([][]int, error) {
+	w, complete := g.weightMatrix()
+	if !complete {
+		return nil, errors.New("Graph is not complete")
+	}
+	if len(tour.Vertices) != g.nVertices {
+		return nil, errors.New("Tour does not visit every vertex exactly once")
+	}
+	seen := make([]bool, g.nVertices+1)
+	for _, v := range tour.Vertices {
+		if v < 1 || v > g.nVertices || seen[v] {
+			return nil, errors.New("Tour does not visit every vertex exactly once")
+		}
+		seen[v] = true
+	}
+	return w, nil
+}
+
+// reverse flips order[i..j] in place
+func reverse(order []int, i, j int) {
+	for ; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+}
+
+// ChristofidesTour builds a closed tour on a complete undirected graph whose
+// weights obey the triangle inequality. It joins a minimum spanning tree with a
+// minimum weight perfect matching of its odd degree vertices and shortcuts the
+// resulting Eulerian circuit, giving a tour at most 1.5 times optimal. The
+// matching is exact at every size, so the bound always holds.
+func (g *Graph) ChristofidesTour(start int) (*Tour, error) {
+	if g.Directed {
+		return nil, errors.New("Christofides requires an undirected graph")
+	}
+	w, complete := g.weightMatrix()
+	if !complete {
+		return nil, errors.New("Graph is not complete")
+	}
+	if start < 1 || start > g.nVertices {
+		return nil, errors.New("Start vertex is not in the graph")
+	}
+	n := g.nVertices
+
+	// Prim's algorithm on the dense weight matrix
+	edges := [][2]int{}
+	inTree := make([]bool, n+1)
+	closest := make([]int, n+1)
+	parent := make([]int, n+1)
+	for i := range closest {
+		closest[i] = math.MaxInt
+	}
+	closest[start] = 0
+	parent[start] = -1
+	for k := 0; k < n; k++ {
+		v := -1
+		for i := 1; i <= n; i++ {
+			if !inTree[i] && (v == -1 || closest[i] < closest[v]) {
+				v = i
+			}
+		}
+		inTree[v] = true
+		if parent[v] != -1 {
+			edges = append(edges, [2]int{parent[v], v})
+		}
+		for y := 1; y <= n; y++ {
+			if !inTree[y] && y != v && w[v][y] < closest[y] {
+				closest[y] = w[v][y]
+				parent[y] = v
+			}
+		}
+	}
+
+	degree := make([]int, n+1)
+	for _, e := range edges {
+		degree[e[0]]++
+		degree[e[1]]++
+	}
+	odd := []int{}
+	for i := 1; i <= n; i++ {
+		if degree[i]%2 == 1 {
+			odd = append(odd, i)
+		}
+	}
+	edges = append(edges, minWeightPerfectMatching(odd, func(a, b int) int { return w[a][b] })...)
+
+	// Shortcut repeated vertices of the Eulerian circuit
+	seen := make(map[int]bool)
+	order := []int{}
+	circuit, _ := eulerianCircuit(edges, start)
+	for _, v := range circuit {
+		if !seen[v] {
+			seen[v] = true
+			order = append(order, v)
+		}
+	}
+	return &Tour{Vertices: order, Cost: tourCost(w, order, true), Closed: true}, nil
+}
@@ -0,0 +1,150 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// initCompleteGraph builds a complete graph over points on a 3x3 grid weighted
+// by Manhattan distance, so the optimal tour has cost 10
+func initCompleteGraph() *Graph {
+	g := NewGraph(false)
+	for i := 1; i <= 9; i++ {
+		for j := i + 1; j <= 9; j++ {
+			xi, yi, xj, yj := (i-1)%3, (i-1)/3, (j-1)%3, (j-1)/3
+			g.InsertWeightedEdge(i, j, abs(xi-xj)+abs(yi-yj), false)
+		}
+	}
+	return g
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func checkTour(t *testing.T, g *Graph, tour *Tour) {
+	seen := make(map[int]bool)
+	for _, v := range tour.Vertices {
+		seen[v] = true
+	}
+	if len(tour.Vertices) != g.NumVertices() || len(seen) != g.NumVertices() {
+		t.Error("Tour does not visit every vertex exactly once")
+	}
+	w, _ := g.weightMatrix()
+	if tour.Cost != tourCost(w, tour.Vertices, tour.Closed) {
+		t.Error("Tour cost does not match its vertices")
+	}
+}
+
+func TestNearestNeighborTour(t *testing.T) {
+	g := initCompleteGraph()
+	tour, err := g.NearestNeighborTour(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTour(t, g, tour)
+	if tour.Vertices[0] != 1 {
+		t.Error("Tour does not begin at the start vertex")
+	}
+
+	_, err = initGraph(false).NearestNeighborTour(1)
+	if err == nil {
+		t.Error("Built a tour over an incomplete graph")
+	}
+}
+
+func TestLocalSearch(t *testing.T) {
+	g := initCompleteGraph()
+	bad := &Tour{Vertices: []int{1, 9, 2, 8, 3, 7, 4, 6, 5}, Closed: true}
+	for _, improve := range []func(*Tour) (*Tour, error){g.TwoOpt, g.OrOpt} {
+		tour, err := improve(bad)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkTour(t, g, tour)
+		w, _ := g.weightMatrix()
+		if tour.Cost >= tourCost(w, bad.Vertices, true) {
+			t.Error("Local search did not improve the tour")
+		}
+	}
+	tour, _ := g.OrOpt(bad)
+	if tour, _ = g.TwoOpt(tour); tour.Cost > 12 {
+		t.Error("Combined local search left a poor tour")
+	}
+}
+
+func TestLocalSearchErrors(t *testing.T) {
+	g := initCompleteGraph()
+	tours := [][]int{
+		{1, 2, 3, 4, 5, 6, 7, 8},
+		{1, 2, 3, 4, 5, 6, 7, 8, 8},
+		{0, 2, 3, 4, 5, 6, 7, 8, 9},
+		{1, 2, 3, 4, 5, 6, 7, 8, 10},
+	}
+	for _, vertices := range tours {
+		for _, improve := range []func(*Tour) (*Tour, error){g.TwoOpt, g.OrOpt} {
+			if _, err := improve(&Tour{Vertices: vertices, Closed: true}); err == nil {
+				t.Errorf("Improved the invalid tour %v", vertices)
+			}
+		}
+	}
+
+	// A missing edge must not be taken as a very cheap one
+	g.RemoveEdge(g.findEdge(1, 2).ID)
+	for _, improve := range []func(*Tour) (*Tour, error){g.TwoOpt, g.OrOpt} {
+		if _, err := improve(&Tour{Vertices: []int{1, 3, 2, 4, 5, 6, 7, 8, 9}, Closed: true}); err == nil {
+			t.Error("Improved a tour over an incomplete graph")
+		}
+	}
+}
+
+func TestLocalSearchDeltas(t *testing.T) {
+	// Moves are priced from the changed edges; check the reported costs and
+	// that no reversal is left that would shorten the tour, including on
+	// directed graphs where a reversed segment costs something different
+	r := rand.New(rand.NewSource(2))
+	for _, directed := range []bool{false, true} {
+		g := NewGraph(directed)
+		for i := 1; i <= 12; i++ {
+			for j := 1; j <= 12; j++ {
+				if i != j && (directed || i < j) {
+					g.InsertWeightedEdge(i, j, r.Intn(50)+1, directed)
+				}
+			}
+		}
+		start := &Tour{Vertices: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Closed: true}
+		w, _ := g.weightMatrix()
+		for _, improve := range []func(*Tour) (*Tour, error){g.TwoOpt, g.OrOpt} {
+			tour, err := improve(start)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkTour(t, g, tour)
+		}
+		tour, _ := g.TwoOpt(start)
+		for i := 0; i < 11; i++ {
+			for j := i + 2; j < 12; j++ {
+				order := append([]int{}, tour.Vertices...)
+				reverse(order, i+1, j)
+				if tourCost(w, order, true) < tour.Cost {
+					t.Errorf("Directed %v: reversing %d..%d still improves the tour", directed, i+1, j)
+				}
+			}
+		}
+	}
+}
+
+func TestChristofidesTour(t *testing.T) {
+	g := initCompleteGraph()
+	tour, err := g.ChristofidesTour(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTour(t, g, tour)
+	if tour.Cost > 15 {
+		t.Error("Christofides tour exceeds 1.5 times the optimum")
+	}
+}
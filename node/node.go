@@ -7,8 +7,6 @@ import (
 	"os"
 )
 
-var Output io.Writer = os.Stdout
-
 // Node is a struct that models a singly-linkedlist
 type Node struct {
 	Next *Node
@@ -23,11 +21,15 @@ func NewNode(data int) *Node {
 	}
 }
 
-// Print traverses the linkedlist and prints out each node
+// Print traverses the linkedlist and prints out each node to standard output
 func (n *Node) Print() {
+	n.Fprint(os.Stdout)
+}
+
+// Fprint traverses the linkedlist and writes out each node to w
+func (n *Node) Fprint(w io.Writer) {
 	for n != nil {
-		// fmt.Println(n)
-		fmt.Fprintln(Output, n.Data)
+		fmt.Fprintln(w, n.Data)
 		n = n.Next
 	}
 }
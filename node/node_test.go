@@ -106,9 +106,8 @@ func initLinkedList() *Node {
 }
 
 func convertToIntSlice(ll *Node) ([]int, error) {
-	Output = bytes.NewBuffer([]byte{})
-	buff := Output.(*bytes.Buffer)
-	ll.Print()
+	buff := bytes.NewBuffer([]byte{})
+	ll.Fprint(buff)
 	values := strings.Split(buff.String(), "\n")
 	final := []int{}
 	for _, v := range values {
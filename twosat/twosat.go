@@ -0,0 +1,123 @@
+// 2-SAT Solver Implementation
+package twosat
+
+import (
+	"fmt"
+
+	"github.com/fabioberger/data-structures/graph"
+)
+
+// A Clause is the disjunction (A OR B) of two literals. Variables are numbered
+// from 1, a positive literal v means "v is true" and -v means "v is false".
+type Clause struct {
+	A int
+	B int
+}
+
+// Formula is a conjunction of two-literal clauses over a fixed set of variables
+type Formula struct {
+	NumVariables int
+	Clauses      []Clause
+}
+
+// NewFormula creates an empty formula over the variables 1..numVariables
+func NewFormula(numVariables int) *Formula {
+	f := new(Formula)
+	f.NumVariables = numVariables
+	return f
+}
+
+// AddClause adds the constraint (a OR b) to the formula
+func (f *Formula) AddClause(a, b int) {
+	f.Clauses = append(f.Clauses, Clause{A: a, B: b})
+}
+
+// UnsatisfiableError is returned when a formula has no satisfying assignment.
+// Core is a subset of the clauses that is unsatisfiable on its own: together
+// they imply both v and NOT v for Variable.
+type UnsatisfiableError struct {
+	Variable int
+	Core     []Clause
+}
+
+func (e *UnsatisfiableError) Error() string {
+	return fmt.Sprintf("Formula is unsatisfiable: %v clauses force variable %v both ways", len(e.Core), e.Variable)
+}
+
+// vertex maps a literal onto the implication graph: variable v is vertex 2v-1
+// and its negation is vertex 2v
+func vertex(literal int) int {
+	if literal > 0 {
+		return 2*literal - 1
+	}
+	return -2 * literal
+}
+
+// ImplicationGraph builds the directed graph with an edge NOT a -> b and an
+// edge NOT b -> a for every clause (a OR b). The second return value records
+// which clause produced each edge.
+func (f *Formula) ImplicationGraph() (*graph.Graph, map[[2]int]Clause) {
+	g := graph.NewGraph(true)
+	g.InsertVertex(2 * f.NumVariables)
+	source := make(map[[2]int]Clause)
+	for _, c := range f.Clauses {
+		g.InsertEdge(vertex(-c.A), vertex(c.B), true)
+		g.InsertEdge(vertex(-c.B), vertex(c.A), true)
+		source[[2]int{vertex(-c.A), vertex(c.B)}] = c
+		source[[2]int{vertex(-c.B), vertex(c.A)}] = c
+	}
+	return g, source
+}
+
+// Solve finds an assignment satisfying every clause, mapping each variable to
+// its value. The formula is unsatisfiable exactly when some variable and its
+// negation share a strongly connected component of the implication graph, in
+// which case an *UnsatisfiableError is returned.
+func (f *Formula) Solve() (map[int]bool, error) {
+	for _, c := range f.Clauses {
+		for _, literal := range []int{c.A, c.B} {
+			if literal == 0 || literal > f.NumVariables || -literal > f.NumVariables {
+				return nil, fmt.Errorf("Literal %v is not a variable of the formula", literal)
+			}
+		}
+	}
+
+	g, source := f.ImplicationGraph()
+	componentOf := make(map[int]int)
+	for id, component := range g.StronglyConnectedComponents() {
+		for _, v := range component {
+			componentOf[v] = id
+		}
+	}
+
+	assignment := make(map[int]bool)
+	for v := 1; v <= f.NumVariables; v++ {
+		pos, neg := componentOf[vertex(v)], componentOf[vertex(-v)]
+		if pos == neg {
+			return nil, &UnsatisfiableError{Variable: v, Core: unsatCore(g, source, v)}
+		}
+		// Components are numbered in reverse topological order, so the literal
+		// whose component comes later in topological order is set true
+		assignment[v] = pos < neg
+	}
+	return assignment, nil
+}
+
+// unsatCore collects the clauses along the implication paths v -> NOT v and
+// NOT v -> v
+func unsatCore(g *graph.Graph, source map[[2]int]Clause, v int) []Clause {
+	c := g.Freeze()
+	core := []Clause{}
+	seen := make(map[Clause]bool)
+	for _, ends := range [][2]int{{vertex(v), vertex(-v)}, {vertex(-v), vertex(v)}} {
+		path, _ := c.FindPath(ends[0], ends[1]) // both paths exist within the component
+		for i := 0; i+1 < len(path); i++ {
+			clause := source[[2]int{path[i], path[i+1]}]
+			if !seen[clause] {
+				seen[clause] = true
+				core = append(core, clause)
+			}
+		}
+	}
+	return core
+}
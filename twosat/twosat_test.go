@@ -0,0 +1,59 @@
+package twosat
+
+import "testing"
+
+func TestSolve(t *testing.T) {
+	f := NewFormula(3)
+	f.AddClause(1, -2)
+	f.AddClause(-1, 2)
+	f.AddClause(-1, -3)
+	f.AddClause(2, 3)
+	assignment, err := f.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range f.Clauses {
+		if !satisfied(assignment, c.A) && !satisfied(assignment, c.B) {
+			t.Errorf("Clause %v is not satisfied", c)
+		}
+	}
+}
+
+func TestUnsatisfiable(t *testing.T) {
+	f := NewFormula(3)
+	f.AddClause(3, -3) // irrelevant tautology
+	f.AddClause(1, 2)
+	f.AddClause(1, -2)
+	f.AddClause(-1, 2)
+	f.AddClause(-1, -2)
+	_, err := f.Solve()
+	unsat, ok := err.(*UnsatisfiableError)
+	if !ok {
+		t.Fatal("Did not detect an unsatisfiable formula")
+	}
+	for _, c := range unsat.Core {
+		if c.A == 3 {
+			t.Error("Unsat core includes an unrelated clause")
+		}
+	}
+	core := NewFormula(2)
+	core.Clauses = unsat.Core
+	if _, err := core.Solve(); err == nil {
+		t.Error("Unsat core is satisfiable on its own")
+	}
+}
+
+func TestInvalidLiteral(t *testing.T) {
+	f := NewFormula(1)
+	f.AddClause(1, 2)
+	if _, err := f.Solve(); err == nil {
+		t.Error("Accepted a literal outside the formula")
+	}
+}
+
+func satisfied(assignment map[int]bool, literal int) bool {
+	if literal > 0 {
+		return assignment[literal]
+	}
+	return !assignment[-literal]
+}